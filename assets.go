@@ -1,8 +1,20 @@
 package go_shopify
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 const assetsBasePath = "themes"
@@ -12,9 +24,19 @@ const assetsBasePath = "themes"
 // See: https://help.shopify.com/api/reference/asset
 type AssetService interface {
 	List(int64, interface{}) ([]Asset, error)
+	ListWithContext(context.Context, int64, interface{}) ([]Asset, error)
 	Get(int64, string) (*Asset, error)
+	GetWithContext(context.Context, int64, string) (*Asset, error)
 	Update(int64, Asset) (*Asset, error)
+	UpdateWithContext(context.Context, int64, Asset) (*Asset, error)
 	Delete(int64, string) error
+	DeleteWithContext(context.Context, int64, string) error
+	Upload(context.Context, int64, string, io.Reader) (*Asset, error)
+	Download(context.Context, int64, string, io.Writer) error
+	Copy(context.Context, int64, string, string) (*Asset, error)
+	Rename(context.Context, int64, string, string) (*Asset, error)
+	CopyToTheme(context.Context, int64, string, int64, string) (*Asset, error)
+	ConditionalUpdate(context.Context, int64, Asset, AssetPrecondition) (*Asset, error)
 }
 
 // AssetServiceOp handles communication with the asset related methods of
@@ -23,19 +45,75 @@ type AssetServiceOp struct {
 	client *Client
 }
 
-// Asset represents a Shopify asset
+// textAssetExtensions are the extensions of assets stored as text in Value,
+// all other assets are stored base64 encoded in Attachment
+var textAssetExtensions = map[string]bool{
+	".liquid": true,
+	".json":   true,
+	".css":    true,
+	".scss":   true,
+	".js":     true,
+	".svg":    true,
+	".txt":    true,
+	".html":   true,
+}
+
+// Asset represents a Shopify asset. Text assets have their content in Value,
+// binary assets like images and fonts have it base64 encoded in Attachment,
+// see Content and NewAssetFromReader.
 type Asset struct {
-	Attachment  string     `json:"attachment"`
+	Attachment  string     `json:"attachment,omitempty"`
+	Checksum    string     `json:"checksum,omitempty"`
 	ContentType string     `json:"content_type"`
 	Key         string     `json:"key"`
 	PublicURL   string     `json:"public_url"`
 	Size        int        `json:"size"`
-	SourceKey   string     `json:"source_key"`
-	Src         string     `json:"src"`
+	SourceKey   string     `json:"source_key,omitempty"`
+	Src         string     `json:"src,omitempty"`
 	ThemeID     int64      `json:"theme_id"`
-	Value       string     `json:"value"`
-	CreatedAt   *time.Time `json:"created_at"`
-	UpdatedAt   *time.Time `json:"updated_at"`
+	Value       string     `json:"value,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// MarshalJSON sends an empty Value unless the asset has an Attachment, or a
+// SourceKey or Src to copy from, so an empty file isn't rejected for having
+// no content
+func (a Asset) MarshalJSON() ([]byte, error) {
+	// asset has the fields of Asset but not this method
+	type asset Asset
+	data := struct {
+		asset
+		Value *string `json:"value,omitempty"`
+	}{asset: asset(a)}
+	if a.Value != "" || (a.Attachment == "" && a.SourceKey == "" && a.Src == "") {
+		data.Value = &a.Value
+	}
+	return json.Marshal(data)
+}
+
+// AssetPrecondition is the state of an asset when it was last read. A
+// conditional update only overwrites the asset if it is still in that state.
+type AssetPrecondition struct {
+	Checksum  string
+	UpdatedAt *time.Time
+}
+
+// AssetConflictError occurs when a conditional update is rejected because
+// the asset was changed, e.g. in the theme editor, since it was last read.
+// Embeds the ResponseError to allow consumers to handle it the same way as a
+// normal ResponseError.
+type AssetConflictError struct {
+	ResponseError
+	Key string
+}
+
+func (e AssetConflictError) Error() string {
+	return fmt.Sprintf("asset %s was changed since it was read: %s", e.Key, e.ResponseError.Error())
+}
+
+func (e AssetConflictError) Unwrap() error {
+	return e.ResponseError
 }
 
 // AssetResource is the result from the themes/x/assets.json?asset[key]= endpoint
@@ -48,6 +126,59 @@ type AssetsResource struct {
 	Assets []Asset `json:"assets"`
 }
 
+// NewAssetFromReader reads the content of an asset from r, e.g. an opened
+// file, and returns an Asset with the content in Value for text assets and
+// base64 encoded in Attachment for binary assets like images and fonts, based
+// on the extension of key or else the detected content type. Content which
+// isn't valid UTF-8 is always sent as Attachment.
+func NewAssetFromReader(key string, r io.Reader) (*Asset, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	asset := &Asset{Key: key}
+	if isTextAsset(key, data) {
+		asset.Value = string(data)
+	} else {
+		asset.Attachment = base64.StdEncoding.EncodeToString(data)
+	}
+	return asset, nil
+}
+
+// Content returns the raw content of an asset, decoding Attachment if the
+// asset has one and returning Value otherwise. Assets returned by
+// AssetService.List have no content.
+func (a *Asset) Content() ([]byte, error) {
+	if a.Attachment != "" {
+		return base64.StdEncoding.DecodeString(a.Attachment)
+	}
+	return []byte(a.Value), nil
+}
+
+// isTextAsset reports whether the content of an asset is stored in Value,
+// based on the extension of its key or else on the content type of data
+func isTextAsset(key string, data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+
+	ext := strings.ToLower(path.Ext(key))
+	if textAssetExtensions[ext] {
+		return true
+	}
+
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	contentType = strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.HasSuffix(contentType, "json") ||
+		strings.HasSuffix(contentType, "javascript") ||
+		contentType == "image/svg+xml"
+}
+
 type assetGetOptions struct {
 	Key     string `url:"asset[key]"`
 	ThemeID int64  `url:"theme_id"`
@@ -55,35 +186,163 @@ type assetGetOptions struct {
 
 // List the metadata for all assets in the given theme
 func (s *AssetServiceOp) List(themeID int64, options interface{}) ([]Asset, error) {
+	return s.ListWithContext(context.Background(), themeID, options)
+}
+
+// ListWithContext lists the metadata for all assets in the given theme
+func (s *AssetServiceOp) ListWithContext(ctx context.Context, themeID int64, options interface{}) ([]Asset, error) {
 	path := fmt.Sprintf("%s/%d/assets.json", assetsBasePath, themeID)
 	resource := new(AssetsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Assets, err
 }
 
 // Get an asset by key from the given theme
 func (s *AssetServiceOp) Get(themeID int64, key string) (*Asset, error) {
+	return s.GetWithContext(context.Background(), themeID, key)
+}
+
+// GetWithContext gets an asset by key from the given theme
+func (s *AssetServiceOp) GetWithContext(ctx context.Context, themeID int64, key string) (*Asset, error) {
+	asset, _, err := s.getWithResponse(ctx, themeID, key)
+	return asset, err
+}
+
+// getWithResponse is like GetWithContext but also returns the Response, see
+// Client.CreateAndDoWithResponse
+func (s *AssetServiceOp) getWithResponse(ctx context.Context, themeID int64, key string) (*Asset, *Response, error) {
 	path := fmt.Sprintf("%s/%d/assets.json", assetsBasePath, themeID)
 	options := assetGetOptions{
 		Key:     key,
 		ThemeID: themeID,
 	}
 	resource := new(AssetResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Asset, err
+	resp, err := s.client.GetWithResponse(ctx, path, resource, options)
+	return resource.Asset, resp, err
 }
 
-// Update an asset
+// Update an asset. Checksum and UpdatedAt are not sent, see ConditionalUpdate.
 func (s *AssetServiceOp) Update(themeID int64, asset Asset) (*Asset, error) {
+	return s.UpdateWithContext(context.Background(), themeID, asset)
+}
+
+// UpdateWithContext updates an asset. Checksum and UpdatedAt are not sent,
+// see ConditionalUpdate.
+func (s *AssetServiceOp) UpdateWithContext(ctx context.Context, themeID int64, asset Asset) (*Asset, error) {
+	// an asset returned by Get has both set, sending them would make the
+	// update conditional
+	asset.Checksum = ""
+	asset.UpdatedAt = nil
+	updated, _, err := s.updateWithResponse(ctx, themeID, asset)
+	return updated, err
+}
+
+// updateWithResponse sends the asset as is and also returns the Response
+func (s *AssetServiceOp) updateWithResponse(ctx context.Context, themeID int64, asset Asset) (*Asset, *Response, error) {
 	path := fmt.Sprintf("%s/%d/assets.json", assetsBasePath, themeID)
 	wrappedData := AssetResource{Asset: &asset}
 	resource := new(AssetResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Asset, err
+	resp, err := s.client.PutWithResponse(ctx, path, wrappedData, resource)
+	return resource.Asset, resp, err
 }
 
 // Delete an asset
 func (s *AssetServiceOp) Delete(themeID int64, key string) error {
-	path := fmt.Sprintf("%s/%d/assets.json?asset[key]=%s", assetsBasePath, themeID, key)
-	return s.client.Delete(path)
+	return s.DeleteWithContext(context.Background(), themeID, key)
+}
+
+// DeleteWithContext deletes an asset
+func (s *AssetServiceOp) DeleteWithContext(ctx context.Context, themeID int64, key string) error {
+	_, err := s.deleteWithResponse(ctx, themeID, key)
+	return err
+}
+
+// deleteWithResponse is like DeleteWithContext but also returns the Response
+func (s *AssetServiceOp) deleteWithResponse(ctx context.Context, themeID int64, key string) (*Response, error) {
+	path := fmt.Sprintf("%s/%d/assets.json?asset[key]=%s", assetsBasePath, themeID, url.QueryEscape(key))
+	return s.client.DeleteWithResponse(ctx, path)
+}
+
+// Upload the content read from r as the asset with the given key, see
+// NewAssetFromReader
+func (s *AssetServiceOp) Upload(ctx context.Context, themeID int64, key string, r io.Reader) (*Asset, error) {
+	asset, _, err := s.uploadWithResponse(ctx, themeID, key, r)
+	return asset, err
+}
+
+// uploadWithResponse is like Upload but also returns the Response
+func (s *AssetServiceOp) uploadWithResponse(ctx context.Context, themeID int64, key string, r io.Reader) (*Asset, *Response, error) {
+	asset, err := NewAssetFromReader(key, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.updateWithResponse(ctx, themeID, *asset)
+}
+
+// Download writes the content of the asset with the given key to w
+func (s *AssetServiceOp) Download(ctx context.Context, themeID int64, key string, w io.Writer) error {
+	asset, err := s.GetWithContext(ctx, themeID, key)
+	if err != nil {
+		return err
+	}
+	content, err := asset.Content()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+// Copy an asset to dstKey within the same theme
+func (s *AssetServiceOp) Copy(ctx context.Context, themeID int64, srcKey string, dstKey string) (*Asset, error) {
+	return s.UpdateWithContext(ctx, themeID, Asset{Key: dstKey, SourceKey: srcKey})
+}
+
+// Rename an asset by copying it to dstKey and deleting srcKey. The copy is
+// returned along with the error if srcKey can't be deleted.
+func (s *AssetServiceOp) Rename(ctx context.Context, themeID int64, srcKey string, dstKey string) (*Asset, error) {
+	asset, err := s.Copy(ctx, themeID, srcKey, dstKey)
+	if err != nil {
+		return nil, err
+	}
+	return asset, s.DeleteWithContext(ctx, themeID, srcKey)
+}
+
+// CopyToTheme copies an asset to dstKey of another theme. Unlike Copy it
+// downloads the content of the asset, source_key only works within a theme.
+func (s *AssetServiceOp) CopyToTheme(ctx context.Context, srcThemeID int64, srcKey string, dstThemeID int64, dstKey string) (*Asset, error) {
+	asset, _, err := s.copyToThemeWithResponse(ctx, srcThemeID, srcKey, dstThemeID, dstKey)
+	return asset, err
+}
+
+// copyToThemeWithResponse is like CopyToTheme but also returns the Response
+// of the last request made
+func (s *AssetServiceOp) copyToThemeWithResponse(ctx context.Context, srcThemeID int64, srcKey string, dstThemeID int64, dstKey string) (*Asset, *Response, error) {
+	src, resp, err := s.getWithResponse(ctx, srcThemeID, srcKey)
+	if err != nil {
+		return nil, resp, err
+	}
+	asset := Asset{
+		Key:        dstKey,
+		Value:      src.Value,
+		Attachment: src.Attachment,
+	}
+	return s.updateWithResponse(ctx, dstThemeID, asset)
+}
+
+// ConditionalUpdate updates an asset only if it is still in the state
+// described by precondition, usually the Checksum and UpdatedAt of the asset
+// when it was last read. Otherwise the asset is left alone and an
+// AssetConflictError is returned.
+func (s *AssetServiceOp) ConditionalUpdate(ctx context.Context, themeID int64, asset Asset, precondition AssetPrecondition) (*Asset, error) {
+	asset.Checksum = precondition.Checksum
+	asset.UpdatedAt = precondition.UpdatedAt
+	updated, _, err := s.updateWithResponse(ctx, themeID, asset)
+
+	var responseError ResponseError
+	if errors.As(err, &responseError) &&
+		(responseError.Status == http.StatusConflict || responseError.Status == http.StatusPreconditionFailed) {
+		return nil, AssetConflictError{ResponseError: responseError, Key: asset.Key}
+	}
+	return updated, err
 }
@@ -1,9 +1,17 @@
 package go_shopify
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 )
@@ -111,3 +119,443 @@ func TestAssetDelete(t *testing.T) {
 		t.Errorf("Asset.Delete returned error: %v", err)
 	}
 }
+
+func TestAssetWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// httpmock ignores the request context so mimic a real transport here
+	responder := func(req *http.Request) (*http.Response, error) {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		return httpmock.NewStringResponse(200, `{"assets": [{"key":"assets\/1.liquid"}]}`), nil
+	}
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		httpmock.RegisterResponder(method,
+			fmt.Sprintf("=~^https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix), responder)
+	}
+
+	assets, err := client.Asset.ListWithContext(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("Asset.ListWithContext returned error: %v", err)
+	}
+	expected := []Asset{{Key: "assets/1.liquid"}}
+	if !reflect.DeepEqual(assets, expected) {
+		t.Errorf("Asset.ListWithContext returned %+v, expected %+v", assets, expected)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.Asset.GetWithContext(ctx, 1, "foo/bar.liquid"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Asset.GetWithContext expected error %v, actual %v", context.Canceled, err)
+	}
+	if _, err := client.Asset.UpdateWithContext(ctx, 1, Asset{Key: "foo/bar.liquid"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Asset.UpdateWithContext expected error %v, actual %v", context.Canceled, err)
+	}
+	if err := client.Asset.DeleteWithContext(ctx, 1, "foo/bar.liquid"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Asset.DeleteWithContext expected error %v, actual %v", context.Canceled, err)
+	}
+}
+
+func TestNewAssetFromReader(t *testing.T) {
+	png := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff}
+
+	cases := []struct {
+		key        string
+		content    []byte
+		value      string
+		attachment string
+	}{
+		{"templates/index.liquid", []byte("{{ content }}"), "{{ content }}", ""},
+		{"config/settings_schema.json", []byte("[]"), "[]", ""},
+		{"assets/icon.svg", []byte("<svg></svg>"), "<svg></svg>", ""},
+		{"assets/theme.css.liquid", []byte("body {}"), "body {}", ""},
+		{"assets/logo.png", png, "", base64.StdEncoding.EncodeToString(png)},
+		{"assets/font.woff2", []byte("wOF2\x00\x01"), "", base64.StdEncoding.EncodeToString([]byte("wOF2\x00\x01"))},
+		// text extension but not valid UTF-8
+		{"assets/latin1.css", []byte("caf\xe9"), "", base64.StdEncoding.EncodeToString([]byte("caf\xe9"))},
+		// unknown extension, detected from the content
+		{"assets/notes.unknown", []byte("plain text"), "plain text", ""},
+	}
+
+	for _, c := range cases {
+		asset, err := NewAssetFromReader(c.key, bytes.NewReader(c.content))
+		if err != nil {
+			t.Errorf("NewAssetFromReader(%s) returned error: %v", c.key, err)
+			continue
+		}
+
+		expected := &Asset{Key: c.key, Value: c.value, Attachment: c.attachment}
+		if !reflect.DeepEqual(asset, expected) {
+			t.Errorf("NewAssetFromReader(%s) returned %+v, expected %+v", c.key, asset, expected)
+		}
+
+		content, err := asset.Content()
+		if err != nil {
+			t.Errorf("Asset.Content() of %s returned error: %v", c.key, err)
+		}
+		if !bytes.Equal(content, c.content) {
+			t.Errorf("Asset.Content() of %s returned %q, expected %q", c.key, content, c.content)
+		}
+	}
+}
+
+func TestAssetContentError(t *testing.T) {
+	asset := Asset{Key: "assets/logo.png", Attachment: "not base64!"}
+	if _, err := asset.Content(); err == nil {
+		t.Errorf("Asset.Content() expected an error")
+	}
+}
+
+func TestAssetUpload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	png := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(200, `{"asset": {"key":"assets\/logo.png","content_type":"image\/png","size":8,"theme_id":1}}`), nil
+		},
+	)
+
+	asset, err := client.Asset.Upload(context.Background(), 1, "assets/logo.png", bytes.NewReader(png))
+	if err != nil {
+		t.Fatalf("Asset.Upload returned error: %v", err)
+	}
+
+	if asset.Key != "assets/logo.png" || asset.ContentType != "image/png" || asset.Size != 8 {
+		t.Errorf("Asset.Upload returned %+v", asset)
+	}
+
+	if sent["asset"]["attachment"] != base64.StdEncoding.EncodeToString(png) {
+		t.Errorf("Asset.Upload sent attachment %v", sent["asset"]["attachment"])
+	}
+	if _, ok := sent["asset"]["value"]; ok {
+		t.Errorf("Asset.Upload sent a value along with the attachment: %+v", sent["asset"])
+	}
+}
+
+func TestAssetUploadEmpty(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(200, `{"asset": {"key":"snippets\/empty.liquid","size":0,"theme_id":1}}`), nil
+		},
+	)
+
+	_, err := client.Asset.Upload(context.Background(), 1, "snippets/empty.liquid", bytes.NewReader(nil))
+	if err != nil {
+		t.Fatalf("Asset.Upload returned error: %v", err)
+	}
+
+	// an asset without any content is rejected
+	value, ok := sent["asset"]["value"]
+	if !ok || value != "" {
+		t.Errorf("Asset.Upload sent %+v, expected an empty value", sent["asset"])
+	}
+	if _, ok := sent["asset"]["attachment"]; ok {
+		t.Errorf("Asset.Upload sent an attachment for an empty asset: %+v", sent["asset"])
+	}
+}
+
+func TestAssetDownload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	png := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+	params := map[string]string{
+		"asset[key]": "assets/logo.png",
+		"theme_id":   "1",
+	}
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(
+			200,
+			fmt.Sprintf(`{"asset": {"key":"assets\/logo.png","attachment":"%s"}}`, base64.StdEncoding.EncodeToString(png)),
+		),
+	)
+
+	buf := new(bytes.Buffer)
+	err := client.Asset.Download(context.Background(), 1, "assets/logo.png", buf)
+	if err != nil {
+		t.Fatalf("Asset.Download returned error: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), png) {
+		t.Errorf("Asset.Download wrote %q, expected %q", buf.Bytes(), png)
+	}
+}
+
+func TestAssetCopy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(200, `{"asset": {"key":"templates\/index.backup.liquid","theme_id":1}}`), nil
+		},
+	)
+
+	asset, err := client.Asset.Copy(context.Background(), 1, "templates/index.liquid", "templates/index.backup.liquid")
+	if err != nil {
+		t.Fatalf("Asset.Copy returned error: %v", err)
+	}
+
+	expected := &Asset{Key: "templates/index.backup.liquid", ThemeID: 1}
+	if !reflect.DeepEqual(asset, expected) {
+		t.Errorf("Asset.Copy returned %+v, expected %+v", asset, expected)
+	}
+
+	if sent["asset"]["key"] != "templates/index.backup.liquid" || sent["asset"]["source_key"] != "templates/index.liquid" {
+		t.Errorf("Asset.Copy sent %+v", sent["asset"])
+	}
+	if _, ok := sent["asset"]["value"]; ok {
+		t.Errorf("Asset.Copy sent a value along with the source key: %+v", sent["asset"])
+	}
+}
+
+func TestAssetRename(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"asset": {"key":"snippets\/new-name.liquid"}}`),
+	)
+
+	params := map[string]string{"asset[key]": "snippets/old name.liquid"}
+	httpmock.RegisterResponderWithQuery(
+		"DELETE",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, "{}"),
+	)
+
+	asset, err := client.Asset.Rename(context.Background(), 1, "snippets/old name.liquid", "snippets/new-name.liquid")
+	if err != nil {
+		t.Fatalf("Asset.Rename returned error: %v", err)
+	}
+
+	if asset.Key != "snippets/new-name.liquid" {
+		t.Errorf("Asset.Rename returned %+v", asset)
+	}
+
+	info := httpmock.GetCallCountInfo()
+	if info["PUT https://fooshop.myshopify.com/"+client.pathPrefix+"/themes/1/assets.json"] != 1 {
+		t.Errorf("Asset.Rename did not copy the asset: %+v", info)
+	}
+}
+
+func TestAssetRenameCopyError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors": {"asset": ["source_key does not exist"]}}`),
+	)
+
+	// no DELETE responder, the source must not be deleted
+	asset, err := client.Asset.Rename(context.Background(), 1, "snippets/missing.liquid", "snippets/new.liquid")
+	if err == nil {
+		t.Fatalf("Asset.Rename returned %+v, expected an error", asset)
+	}
+
+	if _, ok := err.(ResponseError); !ok {
+		t.Errorf("Asset.Rename returned error %T %v, expected a ResponseError", err, err)
+	}
+}
+
+func TestAssetCopyToTheme(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{
+		"asset[key]": "assets/logo.png",
+		"theme_id":   "1",
+	}
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"asset": {"key":"assets\/logo.png","attachment":"iVBORw0KGgo=","content_type":"image\/png","theme_id":1}}`),
+	)
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/2/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(200, `{"asset": {"key":"assets\/brand.png","theme_id":2}}`), nil
+		},
+	)
+
+	asset, err := client.Asset.CopyToTheme(context.Background(), 1, "assets/logo.png", 2, "assets/brand.png")
+	if err != nil {
+		t.Fatalf("Asset.CopyToTheme returned error: %v", err)
+	}
+
+	expected := &Asset{Key: "assets/brand.png", ThemeID: 2}
+	if !reflect.DeepEqual(asset, expected) {
+		t.Errorf("Asset.CopyToTheme returned %+v, expected %+v", asset, expected)
+	}
+
+	if sent["asset"]["key"] != "assets/brand.png" || sent["asset"]["attachment"] != "iVBORw0KGgo=" {
+		t.Errorf("Asset.CopyToTheme sent %+v", sent["asset"])
+	}
+	if _, ok := sent["asset"]["source_key"]; ok {
+		t.Errorf("Asset.CopyToTheme sent a source key: %+v", sent["asset"])
+	}
+}
+
+func TestAssetConditionalUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, loadFixture("asset.json")), nil
+		},
+	)
+
+	updatedAt := time.Date(2017, time.January, 5, 20, 38, 16, 0, time.UTC)
+	precondition := AssetPrecondition{
+		Checksum:  "dcb1b2b3c7c2b7a3a0f5d9c8e5c8d4a1",
+		UpdatedAt: &updatedAt,
+	}
+
+	asset, err := client.Asset.ConditionalUpdate(context.Background(), 1, Asset{Key: "templates/index.liquid", Value: "content"}, precondition)
+	if err != nil {
+		t.Fatalf("Asset.ConditionalUpdate returned error: %v", err)
+	}
+
+	assetTests(t, *asset)
+
+	if sent["asset"]["checksum"] != precondition.Checksum || sent["asset"]["updated_at"] != "2017-01-05T20:38:16Z" {
+		t.Errorf("Asset.ConditionalUpdate sent %+v", sent["asset"])
+	}
+}
+
+func TestAssetConditionalUpdateConflict(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		httpmock.NewStringResponder(409, `{"errors": {"asset": ["has been modified since it was last read"]}}`),
+	)
+
+	precondition := AssetPrecondition{Checksum: "dcb1b2b3c7c2b7a3a0f5d9c8e5c8d4a1"}
+	asset, err := client.Asset.ConditionalUpdate(context.Background(), 1, Asset{Key: "templates/index.liquid", Value: "content"}, precondition)
+	if err == nil {
+		t.Fatalf("Asset.ConditionalUpdate returned %+v, expected an error", asset)
+	}
+
+	var conflict AssetConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Asset.ConditionalUpdate returned error %T %v, expected an AssetConflictError", err, err)
+	}
+
+	if conflict.Key != "templates/index.liquid" || conflict.Status != http.StatusConflict {
+		t.Errorf("Asset.ConditionalUpdate returned %+v", conflict)
+	}
+
+	expectedMessage := "asset templates/index.liquid was changed since it was read: asset: has been modified since it was last read"
+	if conflict.Error() != expectedMessage {
+		t.Errorf("AssetConflictError.Error() returned %q, expected %q", conflict.Error(), expectedMessage)
+	}
+
+	var responseError ResponseError
+	if !errors.As(err, &responseError) || responseError.Status != http.StatusConflict {
+		t.Errorf("Asset.ConditionalUpdate returned error %v, expected it to unwrap to a ResponseError", err)
+	}
+}
+
+func TestAssetUpdateIsNotConditional(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, loadFixture("asset.json")), nil
+		},
+	)
+
+	// an asset as returned by Get
+	updatedAt := time.Date(2017, time.January, 5, 20, 38, 16, 0, time.UTC)
+	asset := Asset{
+		Key:       "templates/index.liquid",
+		Value:     "content",
+		Checksum:  "dcb1b2b3c7c2b7a3a0f5d9c8e5c8d4a1",
+		UpdatedAt: &updatedAt,
+	}
+
+	if _, err := client.Asset.Update(1, asset); err != nil {
+		t.Fatalf("Asset.Update returned error: %v", err)
+	}
+
+	if _, ok := sent["asset"]["checksum"]; ok {
+		t.Errorf("Asset.Update sent checksum %v", sent["asset"]["checksum"])
+	}
+	if _, ok := sent["asset"]["updated_at"]; ok {
+		t.Errorf("Asset.Update sent updated_at %v", sent["asset"]["updated_at"])
+	}
+}
+
+func TestAssetConditionalUpdateError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors": {"asset": ["is invalid"]}}`),
+	)
+
+	_, err := client.Asset.ConditionalUpdate(context.Background(), 1, Asset{Key: "templates/index.liquid"}, AssetPrecondition{})
+	if err == nil {
+		t.Fatalf("Asset.ConditionalUpdate expected an error")
+	}
+
+	if _, ok := err.(AssetConflictError); ok {
+		t.Errorf("Asset.ConditionalUpdate returned an AssetConflictError for a validation error: %v", err)
+	}
+}
@@ -0,0 +1,345 @@
+package go_shopify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// BulkOperationStatus is the status of a bulk operation
+type BulkOperationStatus string
+
+const (
+	BulkOperationStatusCanceled  BulkOperationStatus = "CANCELED"
+	BulkOperationStatusCanceling BulkOperationStatus = "CANCELING"
+	BulkOperationStatusCompleted BulkOperationStatus = "COMPLETED"
+	BulkOperationStatusCreated   BulkOperationStatus = "CREATED"
+	BulkOperationStatusExpired   BulkOperationStatus = "EXPIRED"
+	BulkOperationStatusFailed    BulkOperationStatus = "FAILED"
+	BulkOperationStatusRunning   BulkOperationStatus = "RUNNING"
+)
+
+// Done reports whether the bulk operation stopped running.
+func (s BulkOperationStatus) Done() bool {
+	switch s {
+	case BulkOperationStatusCanceled, BulkOperationStatusCompleted, BulkOperationStatusExpired, BulkOperationStatusFailed:
+		return true
+	}
+	return false
+}
+
+const bulkOperationFields = `id status errorCode createdAt completedAt objectCount fileSize url partialDataUrl query`
+
+const bulkOperationRunQueryMutation = `mutation bulkOperationRunQuery($query: String!) {
+  bulkOperationRunQuery(query: $query) {
+    bulkOperation { ` + bulkOperationFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const currentBulkOperationQuery = `query currentBulkOperation {
+  currentBulkOperation { ` + bulkOperationFields + ` }
+}`
+
+const bulkOperationCancelMutation = `mutation bulkOperationCancel($id: ID!) {
+  bulkOperationCancel(id: $id) {
+    bulkOperation { ` + bulkOperationFields + ` }
+    userErrors { field message }
+  }
+}`
+
+// BulkOperationService is an interface for running bulk queries through the
+// graphql endpoint of the Shopify API and reading their results.
+// See: https://shopify.dev/api/usage/bulk-operations/queries
+type BulkOperationService interface {
+	Run(ctx context.Context, query string) (*BulkOperation, error)
+	Current(ctx context.Context) (*BulkOperation, error)
+	Cancel(ctx context.Context, id string) (*BulkOperation, error)
+	Wait(ctx context.Context, id string, interval time.Duration) (*BulkOperation, error)
+	Download(ctx context.Context, url string) (*BulkOperationResult, error)
+}
+
+// BulkOperationServiceOp handles communication with the bulk operation
+// related queries and mutations of the Shopify API.
+type BulkOperationServiceOp struct {
+	client *Client
+}
+
+// BulkOperation represents a Shopify bulk operation
+type BulkOperation struct {
+	ID             string              `json:"id"`
+	Status         BulkOperationStatus `json:"status"`
+	ErrorCode      string              `json:"errorCode"`
+	CreatedAt      *time.Time          `json:"createdAt"`
+	CompletedAt    *time.Time          `json:"completedAt"`
+	ObjectCount    int64               `json:"objectCount,string"`
+	FileSize       int64               `json:"fileSize,string"`
+	URL            string              `json:"url"`
+	PartialDataURL string              `json:"partialDataUrl"`
+	Query          string              `json:"query"`
+}
+
+// BulkOperationError is returned by Wait when a bulk operation did not
+// complete successfully.
+type BulkOperationError struct {
+	ID        string
+	Status    BulkOperationStatus
+	ErrorCode string
+}
+
+func (e BulkOperationError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("bulk operation %s %s: %s", e.ID, e.Status, e.ErrorCode)
+	}
+	return fmt.Sprintf("bulk operation %s %s", e.ID, e.Status)
+}
+
+// bulkOperationPayload is the payload of the bulk operation mutations
+type bulkOperationPayload struct {
+	BulkOperation *BulkOperation `json:"bulkOperation"`
+}
+
+// Run starts a bulk operation for the given query. Only one bulk operation
+// can run at a time per shop.
+func (s *BulkOperationServiceOp) Run(ctx context.Context, query string) (*BulkOperation, error) {
+	resource := struct {
+		Payload bulkOperationPayload `json:"bulkOperationRunQuery"`
+	}{}
+	vars := map[string]interface{}{"query": query}
+	err := s.client.GraphQL.Query(ctx, bulkOperationRunQueryMutation, vars, &resource)
+	return resource.Payload.BulkOperation, err
+}
+
+// Current returns the most recent bulk operation of the shop, or nil if there
+// is none.
+func (s *BulkOperationServiceOp) Current(ctx context.Context) (*BulkOperation, error) {
+	resource := struct {
+		BulkOperation *BulkOperation `json:"currentBulkOperation"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, currentBulkOperationQuery, nil, &resource)
+	return resource.BulkOperation, err
+}
+
+// Cancel a running bulk operation
+func (s *BulkOperationServiceOp) Cancel(ctx context.Context, id string) (*BulkOperation, error) {
+	resource := struct {
+		Payload bulkOperationPayload `json:"bulkOperationCancel"`
+	}{}
+	vars := map[string]interface{}{"id": id}
+	err := s.client.GraphQL.Query(ctx, bulkOperationCancelMutation, vars, &resource)
+	return resource.Payload.BulkOperation, err
+}
+
+// Wait polls the current bulk operation every interval until the operation
+// with the given id is done. A BulkOperationError is returned along with the
+// operation if it did not complete.
+func (s *BulkOperationServiceOp) Wait(ctx context.Context, id string, interval time.Duration) (*BulkOperation, error) {
+	for {
+		op, err := s.Current(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if op == nil || op.ID != id {
+			return op, fmt.Errorf("bulk operation %s is no longer the current one", id)
+		}
+
+		if op.Status.Done() {
+			if op.Status != BulkOperationStatusCompleted {
+				return op, BulkOperationError{ID: op.ID, Status: op.Status, ErrorCode: op.ErrorCode}
+			}
+			return op, nil
+		}
+
+		s.client.log.Debugf("bulk operation %s %s, polling again in %s", op.ID, op.Status, interval)
+		if err := sleepWithContext(ctx, interval); err != nil {
+			return op, err
+		}
+	}
+}
+
+// Download the JSONL result of a completed bulk operation from its url. The
+// result is streamed, the caller has to close it when done.
+// The url is signed and served outside of Shopify so no credentials are sent.
+// The timeout of the http client doesn't apply since a large result takes a
+// while to read, use ctx to bound the download instead.
+func (s *BulkOperationServiceOp) Download(ctx context.Context, url string) (*BulkOperationResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", UserAgent)
+
+	// http.Client.Timeout includes reading the body
+	httpClient := *s.client.Client
+	httpClient.Timeout = 0
+
+	s.client.log.Debugf("%s: %s", req.Method, req.URL.String())
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, ResponseError{
+			Status:  resp.StatusCode,
+			Message: fmt.Sprintf("could not download bulk operation result: %s", resp.Status),
+		}
+	}
+
+	return NewBulkOperationResult(resp.Body), nil
+}
+
+// BulkObject is a single node of a bulk operation result along with the nodes
+// of its nested connections.
+type BulkObject struct {
+	ID       string
+	ParentID string
+	Data     json.RawMessage
+	Children []*BulkObject
+}
+
+// Decode unmarshals the data of the node into v.
+func (o *BulkObject) Decode(v interface{}) error {
+	return json.Unmarshal(o.Data, v)
+}
+
+// bulkObjectIDs holds the fields linking the lines of a result together
+type bulkObjectIDs struct {
+	ID       string `json:"id"`
+	ParentID string `json:"__parentId"`
+}
+
+// BulkOperationResult reads a JSONL bulk operation result one top level node
+// at a time. Nested connections are written as separate lines right after
+// their parent and referencing it through __parentId, they are attached to
+// the Children of their parent again. It is used like a bufio.Scanner:
+//
+//	for result.Next() {
+//		product := new(Product)
+//		err := result.Object().Decode(product)
+//		// product variants are in result.Object().Children
+//	}
+//	if err := result.Err(); err != nil {
+//		// handle error
+//	}
+type BulkOperationResult struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+
+	object  *BulkObject
+	pending *BulkObject // next top level node, already read
+	err     error
+}
+
+// NewBulkOperationResult returns a BulkOperationResult reading from r.
+func NewBulkOperationResult(r io.ReadCloser) *BulkOperationResult {
+	return &BulkOperationResult{
+		body:   r,
+		reader: bufio.NewReader(r),
+	}
+}
+
+// readObject reads the next line, it returns nil at the end of the result.
+func (r *BulkOperationResult) readObject() (*BulkObject, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			ids := bulkObjectIDs{}
+			if err := json.Unmarshal(line, &ids); err != nil {
+				return nil, err
+			}
+			return &BulkObject{ID: ids.ID, ParentID: ids.ParentID, Data: line}, nil
+		}
+
+		if err == io.EOF {
+			return nil, nil
+		}
+	}
+}
+
+// Next reads the next top level node with all of its children. It returns
+// false at the end of the result or when an error occurred, see Err.
+func (r *BulkOperationResult) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	root := r.pending
+	r.pending = nil
+	if root == nil {
+		root, r.err = r.readObject()
+		if r.err != nil || root == nil {
+			r.object = nil
+			return false
+		}
+	}
+
+	// index of the nodes of this tree to find the parent of each line
+	nodes := map[string]*BulkObject{root.ID: root}
+	for {
+		object, err := r.readObject()
+		if err != nil {
+			r.err = err
+			r.object = nil
+			return false
+		}
+
+		if object == nil {
+			break
+		}
+
+		if object.ParentID == "" {
+			r.pending = object
+			break
+		}
+
+		parent, ok := nodes[object.ParentID]
+		if !ok {
+			r.err = fmt.Errorf("bulk operation result: parent %s of %s not found", object.ParentID, object.ID)
+			r.object = nil
+			return false
+		}
+		parent.Children = append(parent.Children, object)
+		if object.ID != "" {
+			nodes[object.ID] = object
+		}
+	}
+
+	r.object = root
+	return true
+}
+
+// Object returns the node read by the last call to Next.
+func (r *BulkOperationResult) Object() *BulkObject {
+	return r.object
+}
+
+// Decode unmarshals the node read by the last call to Next into v.
+func (r *BulkOperationResult) Decode(v interface{}) error {
+	if r.object == nil {
+		return fmt.Errorf("bulk operation result: no object, call Next first")
+	}
+	return r.object.Decode(v)
+}
+
+// Err returns the first error encountered while reading.
+func (r *BulkOperationResult) Err() error {
+	return r.err
+}
+
+// Close closes the underlying reader.
+func (r *BulkOperationResult) Close() error {
+	return r.body.Close()
+}
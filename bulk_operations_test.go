@@ -0,0 +1,309 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const bulkOperationResultJSONL = `{"id":"gid://shopify/Product/1","title":"Shirt"}
+{"id":"gid://shopify/ProductVariant/11","sku":"shirt-s","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/ProductVariant/12","sku":"shirt-m","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/InventoryLevel/111","available":3,"__parentId":"gid://shopify/ProductVariant/11"}
+{"id":"gid://shopify/Product/2","title":"Hat"}
+
+{"id":"gid://shopify/Product/3","title":"Socks"}
+{"id":"gid://shopify/ProductVariant/31","sku":"socks","__parentId":"gid://shopify/Product/3"}`
+
+func TestBulkOperationRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent graphQLRequest
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(200, `{"data": {"bulkOperationRunQuery": {"bulkOperation": {"id": "gid://shopify/BulkOperation/1", "status": "CREATED"}, "userErrors": []}}}`), nil
+		},
+	)
+
+	query := `{ products { edges { node { id } } } }`
+	op, err := client.BulkOperation.Run(context.Background(), query)
+	if err != nil {
+		t.Fatalf("BulkOperation.Run returned error: %v", err)
+	}
+
+	expected := &BulkOperation{ID: "gid://shopify/BulkOperation/1", Status: BulkOperationStatusCreated}
+	if !reflect.DeepEqual(op, expected) {
+		t.Errorf("BulkOperation.Run returned %+v, expected %+v", op, expected)
+	}
+
+	vars, _ := sent.Variables.(map[string]interface{})
+	if vars["query"] != query || !strings.Contains(sent.Query, "bulkOperationRunQuery") {
+		t.Errorf("BulkOperation.Run sent %+v", sent)
+	}
+}
+
+func TestBulkOperationRunUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data": {"bulkOperationRunQuery": {"bulkOperation": null, "userErrors": [{"field": ["query"], "message": "A bulk query operation for this app and shop is already in progress"}]}}}`),
+	)
+
+	op, err := client.BulkOperation.Run(context.Background(), "{ shop { name } }")
+	expected := UserErrors{{Field: []string{"query"}, Message: "A bulk query operation for this app and shop is already in progress"}}
+	if !reflect.DeepEqual(err, expected) || op != nil {
+		t.Errorf("BulkOperation.Run returned %+v, %#v, expected nil, %#v", op, err, expected)
+	}
+}
+
+func TestBulkOperationWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls int
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 3 {
+				return httpmock.NewStringResponse(200, `{"data": {"currentBulkOperation": {"id": "gid://shopify/BulkOperation/1", "status": "RUNNING", "objectCount": "10", "fileSize": null, "url": null}}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data": {"currentBulkOperation": {"id": "gid://shopify/BulkOperation/1", "status": "COMPLETED", "completedAt": "2021-01-28T19:10:59Z", "objectCount": "20", "fileSize": "1024", "url": "https://storage.example.com/result.jsonl"}}}`), nil
+		},
+	)
+
+	op, err := client.BulkOperation.Wait(context.Background(), "gid://shopify/BulkOperation/1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("BulkOperation.Wait returned error: %v", err)
+	}
+
+	completedAt := time.Date(2021, time.January, 28, 19, 10, 59, 0, time.UTC)
+	expected := &BulkOperation{
+		ID:          "gid://shopify/BulkOperation/1",
+		Status:      BulkOperationStatusCompleted,
+		CompletedAt: &completedAt,
+		ObjectCount: 20,
+		FileSize:    1024,
+		URL:         "https://storage.example.com/result.jsonl",
+	}
+	if !reflect.DeepEqual(op, expected) {
+		t.Errorf("BulkOperation.Wait returned %+v, expected %+v", op, expected)
+	}
+
+	if calls != 3 {
+		t.Errorf("BulkOperation.Wait polled %d times, expected 3", calls)
+	}
+}
+
+func TestBulkOperationWaitFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data": {"currentBulkOperation": {"id": "gid://shopify/BulkOperation/1", "status": "FAILED", "errorCode": "TIMEOUT"}}}`),
+	)
+
+	_, err := client.BulkOperation.Wait(context.Background(), "gid://shopify/BulkOperation/1", time.Millisecond)
+	expected := BulkOperationError{ID: "gid://shopify/BulkOperation/1", Status: BulkOperationStatusFailed, ErrorCode: "TIMEOUT"}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("BulkOperation.Wait returned error %#v, expected %#v", err, expected)
+	}
+
+	_, err = client.BulkOperation.Wait(context.Background(), "gid://shopify/BulkOperation/2", time.Millisecond)
+	if err == nil {
+		t.Errorf("BulkOperation.Wait returned no error for an operation which is not current")
+	}
+}
+
+func TestBulkOperationCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data": {"bulkOperationCancel": {"bulkOperation": {"id": "gid://shopify/BulkOperation/1", "status": "CANCELING"}, "userErrors": []}}}`),
+	)
+
+	op, err := client.BulkOperation.Cancel(context.Background(), "gid://shopify/BulkOperation/1")
+	if err != nil {
+		t.Fatalf("BulkOperation.Cancel returned error: %v", err)
+	}
+
+	if op.Status != BulkOperationStatusCanceling || op.Status.Done() {
+		t.Errorf("BulkOperation.Cancel returned %+v", op)
+	}
+}
+
+func TestBulkOperationDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Shopify-Access-Token") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/result.jsonl" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, bulkOperationResultJSONL)
+	}))
+	defer server.Close()
+
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion))
+
+	result, err := testClient.BulkOperation.Download(context.Background(), server.URL+"/result.jsonl")
+	if err != nil {
+		t.Fatalf("BulkOperation.Download returned error: %v", err)
+	}
+	defer result.Close()
+
+	type variant struct {
+		ID  string `json:"id"`
+		SKU string `json:"sku"`
+	}
+	type product struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+
+	var titles []string
+	var skus [][]string
+	for result.Next() {
+		p := product{}
+		if err := result.Decode(&p); err != nil {
+			t.Fatalf("BulkOperationResult.Decode returned error: %v", err)
+		}
+		titles = append(titles, p.Title)
+
+		var productSKUs []string
+		for _, child := range result.Object().Children {
+			v := variant{}
+			if err := child.Decode(&v); err != nil {
+				t.Fatalf("BulkObject.Decode returned error: %v", err)
+			}
+			productSKUs = append(productSKUs, v.SKU)
+		}
+		skus = append(skus, productSKUs)
+	}
+
+	if err := result.Err(); err != nil {
+		t.Fatalf("BulkOperationResult.Err returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(titles, []string{"Shirt", "Hat", "Socks"}) {
+		t.Errorf("BulkOperationResult returned titles %v", titles)
+	}
+
+	if !reflect.DeepEqual(skus, [][]string{{"shirt-s", "shirt-m"}, nil, {"socks"}}) {
+		t.Errorf("BulkOperationResult returned skus %v", skus)
+	}
+
+	_, err = testClient.BulkOperation.Download(context.Background(), server.URL+"/missing.jsonl")
+	expected := ResponseError{Status: http.StatusNotFound, Message: "could not download bulk operation result: 404 Not Found"}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("BulkOperation.Download returned error %#v, expected %#v", err, expected)
+	}
+}
+
+func TestBulkOperationDownloadSlowBody(t *testing.T) {
+	lines := strings.Split(bulkOperationResultJSONL, "\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, lines[0])
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, strings.Join(lines[1:], "\n"))
+	}))
+	defer server.Close()
+
+	// the body takes longer to read than the timeout of the client
+	testClient := NewClient(app, "fooshop", "abcd",
+		WithVersion(testApiVersion),
+		WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+
+	result, err := testClient.BulkOperation.Download(context.Background(), server.URL+"/result.jsonl")
+	if err != nil {
+		t.Fatalf("BulkOperation.Download returned error: %v", err)
+	}
+	defer result.Close()
+
+	var count int
+	for result.Next() {
+		count++
+	}
+	if err := result.Err(); err != nil {
+		t.Fatalf("BulkOperationResult.Err returned error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("BulkOperationResult returned %d objects, expected 3", count)
+	}
+
+	if testClient.Client.Timeout != 50*time.Millisecond {
+		t.Errorf("BulkOperation.Download changed the client timeout to %s", testClient.Client.Timeout)
+	}
+}
+
+func TestBulkOperationResultNested(t *testing.T) {
+	result := NewBulkOperationResult(ioutil.NopCloser(strings.NewReader(bulkOperationResultJSONL)))
+
+	if !result.Next() {
+		t.Fatalf("BulkOperationResult.Next returned false: %v", result.Err())
+	}
+
+	shirt := result.Object()
+	if len(shirt.Children) != 2 || len(shirt.Children[0].Children) != 1 {
+		t.Fatalf("BulkOperationResult.Object returned %+v", shirt)
+	}
+
+	level := shirt.Children[0].Children[0]
+	if level.ID != "gid://shopify/InventoryLevel/111" || level.ParentID != "gid://shopify/ProductVariant/11" {
+		t.Errorf("BulkOperationResult nested child is %+v", level)
+	}
+}
+
+func TestBulkOperationResultErrors(t *testing.T) {
+	cases := []struct {
+		description string
+		jsonl       string
+	}{
+		{"invalid json", `{"id":"gid://shopify/Product/1"}` + "\n" + `{invalid`},
+		{"unknown parent", `{"id":"gid://shopify/Product/1"}` + "\n" + `{"id":"gid://shopify/ProductVariant/11","__parentId":"gid://shopify/Product/2"}`},
+	}
+
+	for _, c := range cases {
+		result := NewBulkOperationResult(ioutil.NopCloser(strings.NewReader(c.jsonl)))
+		for result.Next() {
+		}
+
+		if result.Err() == nil {
+			t.Errorf("%s: BulkOperationResult.Err returned nil", c.description)
+		}
+	}
+
+	result := NewBulkOperationResult(ioutil.NopCloser(strings.NewReader("")))
+	if result.Next() {
+		t.Errorf("BulkOperationResult.Next returned true for an empty result")
+	}
+	if err := result.Decode(nil); err == nil {
+		t.Errorf("BulkOperationResult.Decode returned no error without an object")
+	}
+}
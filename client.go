@@ -2,6 +2,7 @@ package go_shopify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
@@ -9,8 +10,8 @@ import (
 	"net/url"
 	"path"
 	"regexp"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -56,13 +57,38 @@ type Client struct {
 	token string
 
 	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
+	retries int
 
+	// decides which failures are retried and the delay in between, see WithRetryPolicy
+	retryPolicy RetryPolicy
+
+	// guards apiVersion and RateLimits, which are updated from responses
+	mu sync.RWMutex
+
+	// RateLimits holds the rate limit info of the last successful response.
+	// Deprecated: reading it races with concurrent requests, use
+	// GetRateLimits or the RateLimits of a Response instead.
 	RateLimits RateLimitInfo
 
+	// optional client side leaky bucket, see WithRateLimiter
+	rateLimiter *RateLimiter
+
 	// Services used for communicating with the API
-	Asset AssetService
+	Asset            AssetService
+	GraphQL          GraphQLService
+	BulkOperation    BulkOperationService
+	Product          ProductService
+	Variant          VariantService
+	ProductImage     ProductImageService
+	Order            OrderService
+	Transaction      TransactionService
+	Refund           RefundService
+	Fulfillment      FulfillmentService
+	FulfillmentOrder FulfillmentOrderService
+	Metafield        MetafieldService
+	Customer         CustomerService
+	CustomerAddress  CustomerAddressService
+	Theme            ThemeService
 }
 
 func (c *Client) logRequest(req *http.Request) {
@@ -94,11 +120,18 @@ func (c *Client) logBody(body *io.ReadCloser, format string) {
 	*body = ioutil.NopCloser(bytes.NewBuffer(b))
 }
 
+// Count performs a GET request for the given path and returns the count
+// field of the response.
 func (c *Client) Count(path string, options interface{}) (int, error) {
+	return c.CountWithContext(context.Background(), path, options)
+}
+
+// CountWithContext is like Count but the request is bound to ctx.
+func (c *Client) CountWithContext(ctx context.Context, path string, options interface{}) (int, error) {
 	resource := struct {
 		Count int `json:"count"`
 	}{}
-	err := c.Get(path, &resource, options)
+	err := c.GetWithContext(ctx, path, &resource, options)
 	return resource.Count, err
 }
 
@@ -112,34 +145,49 @@ func (c *Client) Count(path string, options interface{}) (int, error) {
 // parameters like created_at_min
 // Any data returned from Shopify will be marshalled into resource argument.
 func (c *Client) CreateAndDo(method, relPath string, data, options, resource interface{}) error {
-	_, err := c.createAndDoGetHeaders(method, relPath, data, options, resource)
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, data, options, resource)
+}
+
+// CreateAndDoWithContext is like CreateAndDo but the request is bound to ctx.
+// Cancelling ctx aborts the request as well as any pending retry.
+func (c *Client) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, options, resource interface{}) error {
+	_, err := c.createAndDoGetResponse(ctx, method, relPath, data, options, resource)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// createAndDoGetHeaders creates an executes a request while returning the response headers.
-func (c *Client) createAndDoGetHeaders(method, relPath string, data, options, resource interface{}) (http.Header, error) {
+// CreateAndDoWithResponse is like CreateAndDoWithContext but also returns the
+// Response. It is nil only when the request couldn't be prepared, otherwise
+// it is returned even if err is set. StatusCode, Header and RateLimits are
+// then those of the last response received, with StatusCode 0 when Shopify
+// never answered, e.g. after a network error or a cancelled context.
+func (c *Client) CreateAndDoWithResponse(ctx context.Context, method, relPath string, data, options, resource interface{}) (*Response, error) {
+	return c.createAndDoGetResponse(ctx, method, relPath, data, options, resource)
+}
+
+// createAndDoGetResponse creates an executes a request while returning the response details.
+func (c *Client) createAndDoGetResponse(ctx context.Context, method, relPath string, data, options, resource interface{}) (*Response, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 
 	relPath = path.Join(c.pathPrefix, relPath)
-	req, err := c.NewRequest(method, relPath, data, options)
+	req, err := c.NewRequestWithContext(ctx, method, relPath, data, options)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.doGetHeaders(req, resource)
+	return c.doGetResponse(req, resource)
 }
 
 // Do send an API request and populates the given interface with the parsed
 // response. It does not make much sense to call Do without a prepared
 // interface instance.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	_, err := c.doGetHeaders(req, v)
+	_, err := c.doGetResponse(req, v)
 	if err != nil {
 		return err
 	}
@@ -147,108 +195,224 @@ func (c *Client) Do(req *http.Request, v interface{}) error {
 	return nil
 }
 
-// doGetHeaders executes a request, decoding the response into `v` and also returns any response headers.
-func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, error) {
+// DoWithContext is like Do but replaces the context of req with ctx.
+func (c *Client) DoWithContext(ctx context.Context, req *http.Request, v interface{}) error {
+	return c.Do(req.WithContext(ctx), v)
+}
+
+// DoWithResponse is like Do but also returns the Response, see
+// CreateAndDoWithResponse for when it is set.
+func (c *Client) DoWithResponse(req *http.Request, v interface{}) (*Response, error) {
+	return c.doGetResponse(req, v)
+}
+
+// doGetResponse executes a request, decoding the response into `v` and also returns the response details.
+// It only touches shared client state under c.mu so it can be called from several goroutines.
+func (c *Client) doGetResponse(req *http.Request, v interface{}) (*Response, error) {
 	var resp *http.Response
 	var err error
 	retries := c.retries
-	c.attempts = 0
+	response := new(Response)
 	c.logRequest(req)
 
+	rateLimiter := c.rateLimiter
+	if !usesRESTBucket(req) {
+		rateLimiter = nil
+	}
+
+	if err := makeBodyReplayable(req); err != nil {
+		return nil, err
+	}
+
 	for {
-		c.attempts++
-		resp, err = c.Client.Do(req)
-		c.logResponse(resp)
-		if err != nil {
-			return nil, err //http client errors, not api responses
+		if response.Attempts > 0 {
+			// the previous attempt consumed the body, start from a fresh copy
+			if err := rewindBody(req); err != nil {
+				return response, err
+			}
 		}
 
-		respErr := CheckResponseError(resp)
-		if respErr == nil {
-			break // no errors, break out of the retry loop
+		if rateLimiter != nil {
+			if err := rateLimiter.Wait(req.Context()); err != nil {
+				return response, err
+			}
 		}
 
-		// retry scenario, close resp and any continue will retry
-		resp.Body.Close()
+		response.Attempts++
+		resp, err = c.Client.Do(req)
+		c.logResponse(resp)
+
+		var retryErr error
+		if err != nil {
+			// http client errors, not api responses
+			if resp != nil {
+				resp.Body.Close()
+			}
+			resp, retryErr = nil, err
+		} else {
+			response.populate(resp)
+
+			if rateLimiter != nil {
+				if used, size, ok := parseCallLimit(resp.Header); ok {
+					rateLimiter.calibrate(used, size)
+				} else if resp.StatusCode == http.StatusTooManyRequests {
+					rateLimiter.fill()
+				}
+			}
+
+			retryErr = CheckResponseError(resp)
+			if retryErr == nil {
+				break // no errors, break out of the retry loop
+			}
 
-		if retries <= 1 {
-			return nil, respErr
+			// retry scenario, close resp and any continue will retry
+			resp.Body.Close()
 		}
 
-		if rateLimitErr, isRetryErr := respErr.(RateLimitError); isRetryErr {
-			// back off and retry
-
-			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
-			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
-			retries--
-			continue
+		if retries <= 1 || req.Context().Err() != nil {
+			return response, retryErr
 		}
 
-		var doRetry bool
-		switch resp.StatusCode {
-		case http.StatusServiceUnavailable:
-			c.log.Debugf("service unavailable, retrying")
-			doRetry = true
-			retries--
+		policy := c.retryPolicy
+		if policy == nil {
+			policy = DefaultRetryPolicy
 		}
 
-		if doRetry {
-			continue
+		wait, doRetry := policy.ShouldRetry(response.Attempts, resp, retryErr)
+		if !doRetry {
+			// no retry attempts, just return the err
+			return response, retryErr
 		}
 
-		// no retry attempts, just return the err
-		return nil, respErr
+		c.log.Debugf("%s, retrying in %s", retryErr, wait)
+		if err := sleepWithContext(req.Context(), wait); err != nil {
+			return response, err
+		}
+		retries--
 	}
 
 	c.logResponse(resp)
 	defer resp.Body.Close()
 
+	c.mu.Lock()
 	if c.apiVersion == defaultApiVersion && resp.Header.Get("X-Shopify-API-Version") != "" {
 		// if using stable on first request set the api version
 		c.apiVersion = resp.Header.Get("X-Shopify-API-Version")
 		c.log.Infof("api version not set, now using %s", c.apiVersion)
 	}
+	c.RateLimits = response.RateLimits
+	c.mu.Unlock()
 
 	if v != nil {
 		decoder := json.NewDecoder(resp.Body)
 		err := decoder.Decode(&v)
 		if err != nil {
-			return nil, err
+			return response, err
 		}
 	}
 
-	if s := strings.Split(resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(s) == 2 {
-		c.RateLimits.RequestCount, _ = strconv.Atoi(s[0])
-		c.RateLimits.BucketSize, _ = strconv.Atoi(s[1])
+	return response, nil
+}
+
+// makeBodyReplayable makes sure req.GetBody is set so the body can be sent
+// again on retries. Requests from NewRequest already have it, others get their
+// body buffered in memory.
+func makeBodyReplayable(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	b, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+// rewindBody replaces the consumed body of req with a fresh copy.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
 	}
+	req.Body = body
+	return nil
+}
+
+// GetRateLimits returns the rate limit info of the last successful response.
+// Prefer Response.RateLimits when the client is shared between goroutines.
+func (c *Client) GetRateLimits() RateLimitInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	c.RateLimits.RetryAfterSeconds, _ = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	return c.RateLimits
+}
 
-	return resp.Header, nil
+// sleepWithContext pauses for the given duration or until ctx is done,
+// whichever comes first. It returns ctx.Err() if the wait was cut short.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // Get performs a GET request for the given path and saves the result in the
 // given resource.
 func (c *Client) Get(path string, resource, options interface{}) error {
-	return c.CreateAndDo("GET", path, nil, options, resource)
+	return c.GetWithContext(context.Background(), path, resource, options)
+}
+
+// GetWithContext is like Get but the request is bound to ctx.
+func (c *Client) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "GET", path, nil, options, resource)
 }
 
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
-	return c.CreateAndDo("POST", path, data, nil, resource)
+	return c.PostWithContext(context.Background(), path, data, resource)
+}
+
+// PostWithContext is like Post but the request is bound to ctx.
+func (c *Client) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "POST", path, data, nil, resource)
 }
 
 // Put performs a PUT request for the given path and saves the result in the
 // given resource.
 func (c *Client) Put(path string, data, resource interface{}) error {
-	return c.CreateAndDo("PUT", path, data, nil, resource)
+	return c.PutWithContext(context.Background(), path, data, resource)
+}
+
+// PutWithContext is like Put but the request is bound to ctx.
+func (c *Client) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "PUT", path, data, nil, resource)
 }
 
 // Delete performs a DELETE request for the given path
 func (c *Client) Delete(path string) error {
-	return c.CreateAndDo("DELETE", path, nil, nil, nil)
+	return c.DeleteWithContext(context.Background(), path)
+}
+
+// DeleteWithContext is like Delete but the request is bound to ctx.
+func (c *Client) DeleteWithContext(ctx context.Context, path string) error {
+	return c.CreateAndDoWithContext(ctx, "DELETE", path, nil, nil, nil)
 }
 
 // NewRequest Creates an API request. A relative URL can be provided in urlStr, which will
@@ -256,6 +420,12 @@ func (c *Client) Delete(path string) error {
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body.
 func (c *Client) NewRequest(method, relPath string, body, options interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, relPath, body, options)
+}
+
+// NewRequestWithContext is like NewRequest but the returned request is bound
+// to ctx.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, relPath string, body, options interface{}) (*http.Request, error) {
 	rel, err := url.Parse(relPath)
 	if err != nil {
 		return nil, err
@@ -289,7 +459,7 @@ func (c *Client) NewRequest(method, relPath string, body, options interface{}) (
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
 		return nil, err
 	}
@@ -326,15 +496,30 @@ func NewClient(app App, shopName, token string, opts ...Option) *Client {
 		Client: &http.Client{
 			Timeout: time.Second * defaultHttpTimeout,
 		},
-		log:        &LeveledLogger{},
-		app:        app,
-		baseURL:    baseURL,
-		token:      token,
-		apiVersion: defaultApiVersion,
-		pathPrefix: defaultApiPathPrefix,
+		log:         &LeveledLogger{},
+		app:         app,
+		baseURL:     baseURL,
+		token:       token,
+		apiVersion:  defaultApiVersion,
+		pathPrefix:  defaultApiPathPrefix,
+		retryPolicy: DefaultRetryPolicy,
 	}
 
 	c.Asset = &AssetServiceOp{client: c}
+	c.GraphQL = &GraphQLServiceOp{client: c}
+	c.BulkOperation = &BulkOperationServiceOp{client: c}
+	c.Product = &ProductServiceOp{client: c}
+	c.Variant = &VariantServiceOp{client: c}
+	c.ProductImage = &ProductImageServiceOp{client: c}
+	c.Order = &OrderServiceOp{client: c}
+	c.Transaction = &TransactionServiceOp{client: c}
+	c.Refund = &RefundServiceOp{client: c}
+	c.Fulfillment = &FulfillmentServiceOp{client: c}
+	c.FulfillmentOrder = &FulfillmentOrderServiceOp{client: c}
+	c.Metafield = &MetafieldServiceOp{client: c}
+	c.Customer = &CustomerServiceOp{client: c}
+	c.CustomerAddress = &CustomerAddressServiceOp{client: c}
+	c.Theme = &ThemeServiceOp{client: c}
 
 	// apply any options
 	for _, opt := range opts {
@@ -1,6 +1,7 @@
 package go_shopify
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -11,6 +12,7 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -265,7 +267,7 @@ func TestDo(t *testing.T) {
 		{
 			"foo/3",
 			httpmock.NewStringResponder(400, `{"errors": {"title": ["wrong"]}}`),
-			ResponseError{Status: 400, Message: "title: wrong", Errors: []string{"title: wrong"}},
+			ResponseError{Status: 400, Message: "title: wrong", Errors: []string{"title: wrong"}, FieldErrors: map[string][]string{"title": {"wrong"}}},
 		},
 		{
 			"foo/4",
@@ -342,6 +344,13 @@ func TestRetry(t *testing.T) {
 	setup()
 	defer teardown()
 
+	// don't sleep between attempts, Retry-After is still reported in errors
+	WithRetryPolicy(&BackoffRetryPolicy{
+		RetryStatuses: []int{http.StatusServiceUnavailable},
+		BaseDelay:     time.Millisecond,
+		MaxDelay:      time.Millisecond,
+	})(client)
+
 	type MyStruct struct {
 		Foo string `json:"foo"`
 	}
@@ -428,10 +437,10 @@ func TestRetry(t *testing.T) {
 			t.Error("error creating request: ", err)
 		}
 
-		err = client.Do(req, body)
+		resp, err := client.DoWithResponse(req, body)
 
-		if client.attempts != c.retries {
-			t.Errorf("Do(): attempts do not match retries %#v, actual %#v", client.attempts, c.retries)
+		if resp.Attempts != c.retries {
+			t.Errorf("Do(): attempts do not match retries %#v, actual %#v", resp.Attempts, c.retries)
 		}
 
 		if err != nil {
@@ -778,3 +787,198 @@ func TestDoRateLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestGetWithContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// httpmock ignores the request context so mimic a real transport here
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/foo/1", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := req.Context().Err(); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"foo": "bar"}`), nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.GetWithContext(ctx, "foo/1", nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetWithContext(): expected error %v, actual %v", context.Canceled, err)
+	}
+}
+
+func TestNewRequestWithContext(t *testing.T) {
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion))
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "bar")
+
+	req, err := testClient.NewRequestWithContext(ctx, "GET", "foo", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext() err = %v, expected nil", err)
+	}
+
+	if req.Context().Value(ctxKey{}) != "bar" {
+		t.Errorf("NewRequestWithContext() context was not attached to the request")
+	}
+}
+
+func TestRetryContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/foo/1", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service."}`)
+			resp.Header.Add("Retry-After", "10.0")
+			return resp, nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.GetWithContext(ctx, "foo/1", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetWithContext(): expected error %v, actual %v", context.DeadlineExceeded, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("GetWithContext(): retry sleep was not aborted, took %s", elapsed)
+	}
+}
+
+func TestCountWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/foocount", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 5}`))
+
+	cnt, err := client.CountWithContext(context.Background(), "foocount", nil)
+	if err != nil {
+		t.Errorf("Client.CountWithContext returned error: %v", err)
+	}
+
+	expected := 5
+	if cnt != expected {
+		t.Errorf("Client.CountWithContext returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestConcurrentGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testClient := NewClient(app, "fooshop", "abcd", WithRetry(maxRetries))
+	httpmock.ActivateNonDefault(testClient.Client)
+
+	for i := 0; i < 10; i++ {
+		httpmock.RegisterResponder("GET",
+			fmt.Sprintf("https://fooshop.myshopify.com/admin/foo/%d", i),
+			createResponderWithHeaders(200, fmt.Sprintf(`{"foo": "%d"}`, i), map[string]string{
+				"X-Shopify-Shop-Api-Call-Limit": fmt.Sprintf("%d/40", i),
+				"X-Shopify-API-Version":         testApiVersion,
+			}))
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			body := struct {
+				Foo string `json:"foo"`
+			}{}
+			resp, err := testClient.CreateAndDoWithResponse(context.Background(), "GET", fmt.Sprintf("foo/%d", i), nil, nil, &body)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			if body.Foo != fmt.Sprint(i) {
+				errs <- fmt.Errorf("foo/%d: body %q", i, body.Foo)
+			}
+			if resp.Attempts != 1 {
+				errs <- fmt.Errorf("foo/%d: attempts %d", i, resp.Attempts)
+			}
+			if resp.RateLimits.RequestCount != i || resp.RateLimits.BucketSize != 40 {
+				errs <- fmt.Errorf("foo/%d: rate limits %+v", i, resp.RateLimits)
+			}
+
+			_ = testClient.GetRateLimits()
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent CreateAndDoWithResponse(): %v", err)
+	}
+
+	if testClient.apiVersion != testApiVersion {
+		t.Errorf("concurrent CreateAndDoWithResponse(): apiVersion = %s, expected %s", testClient.apiVersion, testApiVersion)
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// don't sleep between attempts, Retry-After is still reported in errors
+	WithRetryPolicy(&BackoffRetryPolicy{
+		RetryStatuses: []int{http.StatusServiceUnavailable},
+		BaseDelay:     time.Millisecond,
+		MaxDelay:      time.Millisecond,
+	})(client)
+
+	var bodies []string
+	responder := func(req *http.Request) (*http.Response, error) {
+		b, _ := ioutil.ReadAll(req.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) < maxRetries {
+			return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{"foo": "bar"}`), nil
+	}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/foo/1", client.pathPrefix), responder)
+	httpmock.RegisterResponder("PUT", "https://fooshop.myshopify.com/foo/2", responder)
+
+	expected := `{"foo":"baz"}`
+	data := struct {
+		Foo string `json:"foo"`
+	}{Foo: "baz"}
+
+	err := client.Post("foo/1", data, nil)
+	if err != nil {
+		t.Fatalf("Post(): errored %s", err)
+	}
+
+	if !reflect.DeepEqual(bodies, []string{expected, expected, expected}) {
+		t.Errorf("Post(): expected every attempt to send %s, actual %q", expected, bodies)
+	}
+
+	// requests built without GetBody are buffered before the first attempt
+	bodies = nil
+	req, err := http.NewRequest("PUT", "https://fooshop.myshopify.com/foo/2", ioutil.NopCloser(strings.NewReader(expected)))
+	if err != nil {
+		t.Fatalf("http.NewRequest(): errored %s", err)
+	}
+
+	err = client.Do(req, nil)
+	if err != nil {
+		t.Fatalf("Do(): errored %s", err)
+	}
+
+	if !reflect.DeepEqual(bodies, []string{expected, expected, expected}) {
+		t.Errorf("Do(): expected every attempt to send %s, actual %q", expected, bodies)
+	}
+}
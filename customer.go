@@ -0,0 +1,290 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const customersBasePath = "customers"
+
+// CustomerService is an interface for interfacing with the customer endpoints
+// of the Shopify API.
+// See: https://help.shopify.com/api/reference/customer
+type CustomerService interface {
+	List(context.Context, interface{}) ([]Customer, error)
+	ListWithPagination(context.Context, interface{}) ([]Customer, *Pagination, error)
+	Count(context.Context, interface{}) (int, error)
+	Get(context.Context, int64, interface{}) (*Customer, error)
+	Create(context.Context, Customer) (*Customer, error)
+	Update(context.Context, Customer) (*Customer, error)
+	Delete(context.Context, int64) error
+	Search(context.Context, string, interface{}) ([]Customer, error)
+	ListOrders(context.Context, int64, interface{}) ([]Order, error)
+	SendInvite(context.Context, int64, CustomerInvite) (*CustomerInvite, error)
+	AccountActivationURL(context.Context, int64) (string, error)
+
+	// MetafieldsService used for Customer resource to communicate with Metafields resource
+	MetafieldsService
+}
+
+// CustomerServiceOp handles communication with the customer related methods of
+// the Shopify API.
+type CustomerServiceOp struct {
+	client *Client
+}
+
+// CustomerState is the state of a customer's account with a shop
+type CustomerState string
+
+const (
+	CustomerStateDisabled CustomerState = "disabled"
+	CustomerStateInvited  CustomerState = "invited"
+	CustomerStateEnabled  CustomerState = "enabled"
+	CustomerStateDeclined CustomerState = "declined"
+)
+
+// CustomerMarketingState is the marketing consent state of a customer
+type CustomerMarketingState string
+
+const (
+	CustomerMarketingStateNotSubscribed CustomerMarketingState = "not_subscribed"
+	CustomerMarketingStatePending       CustomerMarketingState = "pending"
+	CustomerMarketingStateSubscribed    CustomerMarketingState = "subscribed"
+	CustomerMarketingStateUnsubscribed  CustomerMarketingState = "unsubscribed"
+	CustomerMarketingStateRedacted      CustomerMarketingState = "redacted"
+	CustomerMarketingStateInvalid       CustomerMarketingState = "invalid"
+)
+
+// CustomerMarketingOptInLevel is the level of opt in a customer gave when
+// subscribing to marketing
+type CustomerMarketingOptInLevel string
+
+const (
+	CustomerMarketingOptInLevelSingleOptIn    CustomerMarketingOptInLevel = "single_opt_in"
+	CustomerMarketingOptInLevelConfirmedOptIn CustomerMarketingOptInLevel = "confirmed_opt_in"
+	CustomerMarketingOptInLevelUnknown        CustomerMarketingOptInLevel = "unknown"
+)
+
+// CustomerSearchOptions are the options for searching customers besides the
+// query
+type CustomerSearchOptions struct {
+	Order    string `url:"order,omitempty"`
+	Fields   string `url:"fields,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	PageInfo string `url:"page_info,omitempty"`
+}
+
+// Customer represents a Shopify customer
+type Customer struct {
+	ID                    int64                          `json:"id,omitempty"`
+	Email                 string                         `json:"email,omitempty"`
+	FirstName             string                         `json:"first_name,omitempty"`
+	LastName              string                         `json:"last_name,omitempty"`
+	Phone                 string                         `json:"phone,omitempty"`
+	State                 CustomerState                  `json:"state,omitempty"`
+	Note                  string                         `json:"note,omitempty"`
+	Tags                  string                         `json:"tags,omitempty"`
+	Currency              string                         `json:"currency,omitempty"`
+	VerifiedEmail         *bool                          `json:"verified_email,omitempty"`
+	MultipassIdentifier   string                         `json:"multipass_identifier,omitempty"`
+	TaxExempt             *bool                          `json:"tax_exempt,omitempty"`
+	TaxExemptions         []string                       `json:"tax_exemptions,omitempty"`
+	OrdersCount           int                            `json:"orders_count,omitempty"`
+	TotalSpent            *decimal.Decimal               `json:"total_spent,omitempty"`
+	LastOrderID           int64                          `json:"last_order_id,omitempty"`
+	LastOrderName         string                         `json:"last_order_name,omitempty"`
+	CreatedAt             *time.Time                     `json:"created_at,omitempty"`
+	UpdatedAt             *time.Time                     `json:"updated_at,omitempty"`
+	Addresses             []Address                      `json:"addresses,omitempty"`
+	DefaultAddress        *Address                       `json:"default_address,omitempty"`
+	EmailMarketingConsent *CustomerEmailMarketingConsent `json:"email_marketing_consent,omitempty"`
+	SmsMarketingConsent   *CustomerSmsMarketingConsent   `json:"sms_marketing_consent,omitempty"`
+	Metafields            []Metafield                    `json:"metafields,omitempty"`
+	AdminGraphqlAPIID     string                         `json:"admin_graphql_api_id,omitempty"`
+	// Password, PasswordConfirmation, SendEmailInvite and SendEmailWelcome
+	// are only used when creating or updating a customer
+	Password             string `json:"password,omitempty"`
+	PasswordConfirmation string `json:"password_confirmation,omitempty"`
+	SendEmailInvite      bool   `json:"send_email_invite,omitempty"`
+	SendEmailWelcome     bool   `json:"send_email_welcome,omitempty"`
+}
+
+// CustomerEmailMarketingConsent is the email marketing consent of a customer
+type CustomerEmailMarketingConsent struct {
+	State            CustomerMarketingState      `json:"state,omitempty"`
+	OptInLevel       CustomerMarketingOptInLevel `json:"opt_in_level,omitempty"`
+	ConsentUpdatedAt *time.Time                  `json:"consent_updated_at,omitempty"`
+}
+
+// CustomerSmsMarketingConsent is the SMS marketing consent of a customer
+type CustomerSmsMarketingConsent struct {
+	State                CustomerMarketingState      `json:"state,omitempty"`
+	OptInLevel           CustomerMarketingOptInLevel `json:"opt_in_level,omitempty"`
+	ConsentUpdatedAt     *time.Time                  `json:"consent_updated_at,omitempty"`
+	ConsentCollectedFrom string                      `json:"consent_collected_from,omitempty"`
+}
+
+// CustomerInvite is an account invite sent to a customer. Empty fields use the
+// defaults of the shop's customer account invite template.
+type CustomerInvite struct {
+	To            string   `json:"to,omitempty"`
+	From          string   `json:"from,omitempty"`
+	Bcc           []string `json:"bcc,omitempty"`
+	Subject       string   `json:"subject,omitempty"`
+	CustomMessage string   `json:"custom_message,omitempty"`
+}
+
+// CustomerResource represents the result from the customers/X.json endpoint
+type CustomerResource struct {
+	Customer *Customer `json:"customer"`
+}
+
+// CustomersResource represents the result from the customers.json endpoint
+type CustomersResource struct {
+	Customers []Customer `json:"customers"`
+}
+
+// CustomerInviteResource represents the result from the
+// customers/X/send_invite.json endpoint
+type CustomerInviteResource struct {
+	CustomerInvite *CustomerInvite `json:"customer_invite"`
+}
+
+// CustomerAccountActivationURLResource represents the result from the
+// customers/X/account_activation_url.json endpoint
+type CustomerAccountActivationURLResource struct {
+	AccountActivationURL string `json:"account_activation_url"`
+}
+
+// List customers
+func (s *CustomerServiceOp) List(ctx context.Context, options interface{}) ([]Customer, error) {
+	customers, _, err := s.ListWithPagination(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
+// ListWithPagination lists customers and returns the pagination to retrieve
+// the next or previous page.
+func (s *CustomerServiceOp) ListWithPagination(ctx context.Context, options interface{}) ([]Customer, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", customersBasePath)
+	resource := new(CustomersResource)
+	pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resource.Customers, pagination, nil
+}
+
+// Count customers
+func (s *CustomerServiceOp) Count(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/count.json", customersBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual customer
+func (s *CustomerServiceOp) Get(ctx context.Context, customerID int64, options interface{}) (*Customer, error) {
+	path := fmt.Sprintf("%s/%d.json", customersBasePath, customerID)
+	resource := new(CustomerResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Customer, err
+}
+
+// Create a new customer
+func (s *CustomerServiceOp) Create(ctx context.Context, customer Customer) (*Customer, error) {
+	path := fmt.Sprintf("%s.json", customersBasePath)
+	wrappedData := CustomerResource{Customer: &customer}
+	resource := new(CustomerResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Customer, err
+}
+
+// Update an existing customer
+func (s *CustomerServiceOp) Update(ctx context.Context, customer Customer) (*Customer, error) {
+	path := fmt.Sprintf("%s/%d.json", customersBasePath, customer.ID)
+	wrappedData := CustomerResource{Customer: &customer}
+	resource := new(CustomerResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Customer, err
+}
+
+// Delete an existing customer
+func (s *CustomerServiceOp) Delete(ctx context.Context, customerID int64) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", customersBasePath, customerID))
+}
+
+// Search customers matching query, e.g. "email:bob@example.com" or
+// "country:Canada". Options are usually CustomerSearchOptions.
+func (s *CustomerServiceOp) Search(ctx context.Context, query string, options interface{}) ([]Customer, error) {
+	path := fmt.Sprintf("%s/search.json?query=%s", customersBasePath, url.QueryEscape(query))
+	resource := new(CustomersResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Customers, err
+}
+
+// ListOrders lists the orders of a customer
+func (s *CustomerServiceOp) ListOrders(ctx context.Context, customerID int64, options interface{}) ([]Order, error) {
+	path := fmt.Sprintf("%s/%d/orders.json", customersBasePath, customerID)
+	resource := new(OrdersResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Orders, err
+}
+
+// SendInvite sends an account invite to a customer
+func (s *CustomerServiceOp) SendInvite(ctx context.Context, customerID int64, invite CustomerInvite) (*CustomerInvite, error) {
+	path := fmt.Sprintf("%s/%d/send_invite.json", customersBasePath, customerID)
+	wrappedData := CustomerInviteResource{CustomerInvite: &invite}
+	resource := new(CustomerInviteResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.CustomerInvite, err
+}
+
+// AccountActivationURL creates a single use URL a customer can use to activate
+// their account. It fails for customers whose account is already enabled.
+func (s *CustomerServiceOp) AccountActivationURL(ctx context.Context, customerID int64) (string, error) {
+	path := fmt.Sprintf("%s/%d/account_activation_url.json", customersBasePath, customerID)
+	resource := new(CustomerAccountActivationURLResource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
+	return resource.AccountActivationURL, err
+}
+
+// ListMetafields for a customer
+func (s *CustomerServiceOp) ListMetafields(ctx context.Context, customerID int64, options interface{}) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersBasePath, resourceID: customerID}
+	return metafieldService.List(ctx, options)
+}
+
+// CountMetafields for a customer
+func (s *CustomerServiceOp) CountMetafields(ctx context.Context, customerID int64, options interface{}) (int, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersBasePath, resourceID: customerID}
+	return metafieldService.Count(ctx, options)
+}
+
+// GetMetafield for a customer
+func (s *CustomerServiceOp) GetMetafield(ctx context.Context, customerID int64, metafieldID int64, options interface{}) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersBasePath, resourceID: customerID}
+	return metafieldService.Get(ctx, metafieldID, options)
+}
+
+// CreateMetafield for a customer
+func (s *CustomerServiceOp) CreateMetafield(ctx context.Context, customerID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersBasePath, resourceID: customerID}
+	return metafieldService.Create(ctx, metafield)
+}
+
+// UpdateMetafield for a customer
+func (s *CustomerServiceOp) UpdateMetafield(ctx context.Context, customerID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersBasePath, resourceID: customerID}
+	return metafieldService.Update(ctx, metafield)
+}
+
+// DeleteMetafield for a customer
+func (s *CustomerServiceOp) DeleteMetafield(ctx context.Context, customerID int64, metafieldID int64) error {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersBasePath, resourceID: customerID}
+	return metafieldService.Delete(ctx, metafieldID)
+}
@@ -0,0 +1,124 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// CustomerAddressService is an interface for interfacing with the customer
+// address endpoints of the Shopify API.
+// See: https://help.shopify.com/api/reference/customeraddress
+type CustomerAddressService interface {
+	List(context.Context, int64, interface{}) ([]Address, error)
+	Get(context.Context, int64, int64, interface{}) (*Address, error)
+	Create(context.Context, int64, Address) (*Address, error)
+	Update(context.Context, int64, Address) (*Address, error)
+	Delete(context.Context, int64, int64) error
+	SetDefault(context.Context, int64, int64) (*Address, error)
+	BulkDelete(context.Context, int64, []int64) error
+}
+
+// CustomerAddressServiceOp handles communication with the customer address
+// related methods of the Shopify API.
+type CustomerAddressServiceOp struct {
+	client *Client
+}
+
+// Address represents a customer address, or the billing or shipping address
+// of an order. CustomerID, CountryName and Default are only set on customer
+// addresses.
+type Address struct {
+	ID           int64   `json:"id,omitempty"`
+	CustomerID   int64   `json:"customer_id,omitempty"`
+	FirstName    string  `json:"first_name,omitempty"`
+	LastName     string  `json:"last_name,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	Company      string  `json:"company,omitempty"`
+	Address1     string  `json:"address1,omitempty"`
+	Address2     string  `json:"address2,omitempty"`
+	City         string  `json:"city,omitempty"`
+	Province     string  `json:"province,omitempty"`
+	ProvinceCode string  `json:"province_code,omitempty"`
+	Country      string  `json:"country,omitempty"`
+	CountryCode  string  `json:"country_code,omitempty"`
+	CountryName  string  `json:"country_name,omitempty"`
+	Zip          string  `json:"zip,omitempty"`
+	Phone        string  `json:"phone,omitempty"`
+	Latitude     float64 `json:"latitude,omitempty"`
+	Longitude    float64 `json:"longitude,omitempty"`
+	Default      bool    `json:"default,omitempty"`
+}
+
+// CustomerAddressResource represents the result from the
+// customers/X/addresses/Y.json endpoint
+type CustomerAddressResource struct {
+	Address *Address `json:"customer_address"`
+}
+
+// CustomerAddressesResource represents the result from the
+// customers/X/addresses.json endpoint
+type CustomerAddressesResource struct {
+	Addresses []Address `json:"addresses"`
+}
+
+// customerAddressSetOptions are the query parameters of the
+// customers/X/addresses/set.json endpoint
+type customerAddressSetOptions struct {
+	AddressIDs []int64 `url:"address_ids[]"`
+	Operation  string  `url:"operation"`
+}
+
+// List addresses of a customer
+func (s *CustomerAddressServiceOp) List(ctx context.Context, customerID int64, options interface{}) ([]Address, error) {
+	path := fmt.Sprintf("%s/%d/addresses.json", customersBasePath, customerID)
+	resource := new(CustomerAddressesResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Addresses, err
+}
+
+// Get individual address of a customer
+func (s *CustomerAddressServiceOp) Get(ctx context.Context, customerID int64, addressID int64, options interface{}) (*Address, error) {
+	path := fmt.Sprintf("%s/%d/addresses/%d.json", customersBasePath, customerID, addressID)
+	resource := new(CustomerAddressResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Address, err
+}
+
+// Create a new address for a customer
+func (s *CustomerAddressServiceOp) Create(ctx context.Context, customerID int64, address Address) (*Address, error) {
+	path := fmt.Sprintf("%s/%d/addresses.json", customersBasePath, customerID)
+	wrappedData := CustomerAddressResource{Address: &address}
+	resource := new(CustomerAddressResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Address, err
+}
+
+// Update an existing address of a customer
+func (s *CustomerAddressServiceOp) Update(ctx context.Context, customerID int64, address Address) (*Address, error) {
+	path := fmt.Sprintf("%s/%d/addresses/%d.json", customersBasePath, customerID, address.ID)
+	wrappedData := CustomerAddressResource{Address: &address}
+	resource := new(CustomerAddressResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Address, err
+}
+
+// Delete an existing address of a customer. The default address of a
+// customer can't be deleted.
+func (s *CustomerAddressServiceOp) Delete(ctx context.Context, customerID int64, addressID int64) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d/addresses/%d.json", customersBasePath, customerID, addressID))
+}
+
+// SetDefault makes an existing address the default address of a customer
+func (s *CustomerAddressServiceOp) SetDefault(ctx context.Context, customerID int64, addressID int64) (*Address, error) {
+	path := fmt.Sprintf("%s/%d/addresses/%d/default.json", customersBasePath, customerID, addressID)
+	resource := new(CustomerAddressResource)
+	err := s.client.PutWithContext(ctx, path, nil, resource)
+	return resource.Address, err
+}
+
+// BulkDelete deletes multiple addresses of a customer at once
+func (s *CustomerAddressServiceOp) BulkDelete(ctx context.Context, customerID int64, addressIDs []int64) error {
+	path := fmt.Sprintf("%s/%d/addresses/set.json", customersBasePath, customerID)
+	options := customerAddressSetOptions{AddressIDs: addressIDs, Operation: "destroy"}
+	return s.client.CreateAndDoWithContext(ctx, "PUT", path, nil, options, nil)
+}
@@ -0,0 +1,181 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func customerAddressTests(t *testing.T, address Address) {
+	expected := Address{
+		ID:           207119551,
+		CustomerID:   207119551,
+		Address1:     "Chestnut Street 92",
+		City:         "Louisville",
+		Province:     "Kentucky",
+		ProvinceCode: "KY",
+		Country:      "United States",
+		CountryCode:  "US",
+		CountryName:  "United States",
+		Zip:          "40202",
+		Phone:        "555-625-1199",
+		Default:      true,
+	}
+	if !reflect.DeepEqual(address, expected) {
+		t.Errorf("Address returned %+v, expected %+v", address, expected)
+	}
+}
+
+func TestCustomerAddressList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/addresses.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("customer_addresses.json")))
+
+	addresses, err := client.CustomerAddress.List(context.Background(), 207119551, nil)
+	if err != nil {
+		t.Fatalf("CustomerAddress.List returned error: %v", err)
+	}
+
+	if len(addresses) != 2 {
+		t.Fatalf("CustomerAddress.List returned %d addresses, expected 2", len(addresses))
+	}
+
+	customerAddressTests(t, addresses[0])
+
+	if addresses[1].ID != 1053317288 || addresses[1].Name != "Samuel de Champlain" || addresses[1].Default {
+		t.Errorf("CustomerAddress.List returned %+v as second address", addresses[1])
+	}
+}
+
+func TestCustomerAddressGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/addresses/207119551.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("customer_address.json")))
+
+	address, err := client.CustomerAddress.Get(context.Background(), 207119551, 207119551, nil)
+	if err != nil {
+		t.Fatalf("CustomerAddress.Get returned error: %v", err)
+	}
+
+	customerAddressTests(t, *address)
+}
+
+func TestCustomerAddressCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/addresses.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(201, loadFixture("customer_address.json")), nil
+		})
+
+	address := Address{
+		Address1:    "Chestnut Street 92",
+		City:        "Louisville",
+		CountryCode: "US",
+		Zip:         "40202",
+	}
+
+	returnedAddress, err := client.CustomerAddress.Create(context.Background(), 207119551, address)
+	if err != nil {
+		t.Fatalf("CustomerAddress.Create returned error: %v", err)
+	}
+
+	customerAddressTests(t, *returnedAddress)
+
+	expectedSent := map[string]interface{}{
+		"address1":     "Chestnut Street 92",
+		"city":         "Louisville",
+		"country_code": "US",
+		"zip":          "40202",
+	}
+	if !reflect.DeepEqual(sent["customer_address"], expectedSent) {
+		t.Errorf("CustomerAddress.Create sent %+v, expected %+v", sent["customer_address"], expectedSent)
+	}
+}
+
+func TestCustomerAddressUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/addresses/207119551.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("customer_address.json")))
+
+	address := Address{
+		ID:  207119551,
+		Zip: "40202",
+	}
+
+	returnedAddress, err := client.CustomerAddress.Update(context.Background(), 207119551, address)
+	if err != nil {
+		t.Fatalf("CustomerAddress.Update returned error: %v", err)
+	}
+
+	customerAddressTests(t, *returnedAddress)
+}
+
+func TestCustomerAddressDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1/addresses/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.CustomerAddress.Delete(context.Background(), 1, 2)
+	if err != nil {
+		t.Errorf("CustomerAddress.Delete returned error: %v", err)
+	}
+}
+
+func TestCustomerAddressSetDefault(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/addresses/207119551/default.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("customer_address.json")))
+
+	address, err := client.CustomerAddress.SetDefault(context.Background(), 207119551, 207119551)
+	if err != nil {
+		t.Fatalf("CustomerAddress.SetDefault returned error: %v", err)
+	}
+
+	customerAddressTests(t, *address)
+}
+
+func TestCustomerAddressBulkDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query map[string][]string
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/addresses/set.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	err := client.CustomerAddress.BulkDelete(context.Background(), 207119551, []int64{1053317288, 1053317289})
+	if err != nil {
+		t.Fatalf("CustomerAddress.BulkDelete returned error: %v", err)
+	}
+
+	expected := map[string][]string{
+		"address_ids[]": {"1053317288", "1053317289"},
+		"operation":     {"destroy"},
+	}
+	if !reflect.DeepEqual(query, expected) {
+		t.Errorf("CustomerAddress.BulkDelete sent query %+v, expected %+v", query, expected)
+	}
+}
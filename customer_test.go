@@ -0,0 +1,435 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func customerTests(t *testing.T, customer Customer) {
+	// Check that ID is assigned to the returned customer
+	var expectedInt int64 = 207119551
+	if customer.ID != expectedInt {
+		t.Errorf("Customer.ID returned %+v, expected %+v", customer.ID, expectedInt)
+	}
+
+	cases := []struct {
+		field    string
+		actual   string
+		expected string
+	}{
+		{"Email", customer.Email, "bob.norman@mail.example.com"},
+		{"FirstName", customer.FirstName, "Bob"},
+		{"LastName", customer.LastName, "Norman"},
+		{"State", string(customer.State), string(CustomerStateDisabled)},
+		{"Tags", customer.Tags, "Léon, Noël"},
+		{"LastOrderName", customer.LastOrderName, "#1001"},
+		{"Phone", customer.Phone, "+16136120707"},
+		{"AdminGraphqlAPIID", customer.AdminGraphqlAPIID, "gid://shopify/Customer/207119551"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("Customer.%s returned %+v, expected %+v", c.field, c.actual, c.expected)
+		}
+	}
+
+	expectedTime := time.Date(2022, time.April, 5, 17, 5, 24, 0, time.UTC)
+	if customer.CreatedAt == nil || !customer.CreatedAt.Equal(expectedTime) {
+		t.Errorf("Customer.CreatedAt returned %+v, expected %+v", customer.CreatedAt, expectedTime)
+	}
+
+	expectedSpent := decimal.RequireFromString("199.65")
+	if customer.TotalSpent == nil || !customer.TotalSpent.Equal(expectedSpent) {
+		t.Errorf("Customer.TotalSpent returned %v, expected %v", customer.TotalSpent, expectedSpent)
+	}
+
+	if customer.VerifiedEmail == nil || !*customer.VerifiedEmail || customer.TaxExempt == nil || *customer.TaxExempt {
+		t.Errorf("Customer.VerifiedEmail/TaxExempt returned %v/%v, expected true/false", customer.VerifiedEmail, customer.TaxExempt)
+	}
+
+	expectedExemptions := []string{"CA_STATUS_CARD_EXEMPTION", "CA_DIPLOMAT_EXEMPTION"}
+	if !reflect.DeepEqual(customer.TaxExemptions, expectedExemptions) {
+		t.Errorf("Customer.TaxExemptions returned %+v, expected %+v", customer.TaxExemptions, expectedExemptions)
+	}
+
+	if len(customer.Addresses) != 1 || customer.Addresses[0].Address1 != "Chestnut Street 92" || customer.Addresses[0].ProvinceCode != "KY" {
+		t.Errorf("Customer.Addresses returned %+v", customer.Addresses)
+	}
+	if customer.DefaultAddress == nil {
+		t.Errorf("Customer.DefaultAddress returned nil")
+	} else {
+		customerAddressTests(t, *customer.DefaultAddress)
+	}
+
+	emailConsent := customer.EmailMarketingConsent
+	expectedConsentTime := time.Date(2022, time.April, 1, 15, 22, 33, 0, time.UTC)
+	if emailConsent == nil ||
+		emailConsent.State != CustomerMarketingStateSubscribed ||
+		emailConsent.OptInLevel != CustomerMarketingOptInLevelConfirmedOptIn ||
+		emailConsent.ConsentUpdatedAt == nil ||
+		!emailConsent.ConsentUpdatedAt.Equal(expectedConsentTime) {
+		t.Errorf("Customer.EmailMarketingConsent returned %+v", emailConsent)
+	}
+
+	expectedSmsConsent := &CustomerSmsMarketingConsent{
+		State:                CustomerMarketingStateNotSubscribed,
+		OptInLevel:           CustomerMarketingOptInLevelSingleOptIn,
+		ConsentCollectedFrom: "OTHER",
+	}
+	if !reflect.DeepEqual(customer.SmsMarketingConsent, expectedSmsConsent) {
+		t.Errorf("Customer.SmsMarketingConsent returned %+v, expected %+v", customer.SmsMarketingConsent, expectedSmsConsent)
+	}
+}
+
+func TestCustomerList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("customers.json")))
+
+	customers, err := client.Customer.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Customer.List returned error: %v", err)
+	}
+
+	if len(customers) != 2 {
+		t.Fatalf("Customer.List returned %d customers, expected 2", len(customers))
+	}
+
+	customerTests(t, customers[0])
+
+	if customers[1].ID != 1073339459 || customers[1].State != CustomerStateEnabled {
+		t.Errorf("Customer.List returned %+v as second customer", customers[1])
+	}
+}
+
+func TestCustomerListWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix)
+
+	httpmock.RegisterResponder("GET", listURL,
+		httpmock.ResponderFromResponse(&http.Response{
+			StatusCode: 200,
+			Body:       httpmock.NewRespBodyFromString(`{"customers": [{"id":1},{"id":2}]}`),
+			Header: http.Header{
+				"Link": {`<http://valid.url?page_info=pageInfoCode&limit=2>; rel="next"`},
+			},
+		}))
+
+	customers, pagination, err := client.Customer.ListWithPagination(context.Background(), ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("Customer.ListWithPagination returned error: %v", err)
+	}
+
+	expected := []Customer{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(customers, expected) {
+		t.Errorf("Customer.ListWithPagination returned %+v, expected %+v", customers, expected)
+	}
+
+	expectedPage := &ListOptions{PageInfo: "pageInfoCode", Limit: 2}
+	if pagination == nil || !reflect.DeepEqual(pagination.NextPageOptions, expectedPage) {
+		t.Errorf("Customer.ListWithPagination returned pagination %+v, expected next page %+v", pagination, expectedPage)
+	}
+}
+
+func TestCustomerCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 5}`))
+
+	cnt, err := client.Customer.Count(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Customer.Count returned error: %v", err)
+	}
+
+	expected := 5
+	if cnt != expected {
+		t.Errorf("Customer.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestCustomerGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("customer.json")))
+
+	customer, err := client.Customer.Get(context.Background(), 207119551, nil)
+	if err != nil {
+		t.Fatalf("Customer.Get returned error: %v", err)
+	}
+
+	customerTests(t, *customer)
+}
+
+func TestCustomerCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(201, loadFixture("customer.json")), nil
+		})
+
+	customer := Customer{
+		Email:         "bob.norman@mail.example.com",
+		FirstName:     "Bob",
+		LastName:      "Norman",
+		TaxExemptions: []string{"CA_STATUS_CARD_EXEMPTION"},
+		EmailMarketingConsent: &CustomerEmailMarketingConsent{
+			State:      CustomerMarketingStateSubscribed,
+			OptInLevel: CustomerMarketingOptInLevelConfirmedOptIn,
+		},
+		Addresses:       []Address{{Address1: "Chestnut Street 92", City: "Louisville"}},
+		SendEmailInvite: true,
+	}
+
+	returnedCustomer, err := client.Customer.Create(context.Background(), customer)
+	if err != nil {
+		t.Fatalf("Customer.Create returned error: %v", err)
+	}
+
+	customerTests(t, *returnedCustomer)
+
+	if sent["customer"]["send_email_invite"] != true {
+		t.Errorf("Customer.Create sent %+v", sent)
+	}
+
+	consent, _ := sent["customer"]["email_marketing_consent"].(map[string]interface{})
+	if consent["state"] != "subscribed" || consent["opt_in_level"] != "confirmed_opt_in" {
+		t.Errorf("Customer.Create sent email marketing consent %+v", consent)
+	}
+}
+
+func TestCustomerUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("customer.json")))
+
+	customer := Customer{
+		ID:   207119551,
+		Tags: "Léon, Noël",
+	}
+
+	returnedCustomer, err := client.Customer.Update(context.Background(), customer)
+	if err != nil {
+		t.Fatalf("Customer.Update returned error: %v", err)
+	}
+
+	customerTests(t, *returnedCustomer)
+}
+
+func TestCustomerUpdateTaxExempt(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(b, &sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("customer.json")), nil
+		})
+
+	taxExempt := false
+	_, err := client.Customer.Update(context.Background(), Customer{ID: 207119551, TaxExempt: &taxExempt})
+	if err != nil {
+		t.Fatalf("Customer.Update returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":         float64(207119551),
+		"tax_exempt": false,
+	}
+	if !reflect.DeepEqual(sent["customer"], expected) {
+		t.Errorf("Customer.Update sent %+v, expected %+v", sent["customer"], expected)
+	}
+}
+
+func TestCustomerDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Customer.Delete(context.Background(), 1)
+	if err != nil {
+		t.Errorf("Customer.Delete returned error: %v", err)
+	}
+}
+
+func TestCustomerSearch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"query": "email:bob.norman@mail.example.com", "limit": "1"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/search.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"customers": [{"id":207119551}]}`))
+
+	options := CustomerSearchOptions{
+		Limit: 1,
+	}
+
+	customers, err := client.Customer.Search(context.Background(), "email:bob.norman@mail.example.com", options)
+	if err != nil {
+		t.Errorf("Customer.Search returned error: %v", err)
+	}
+
+	expected := []Customer{{ID: 207119551}}
+	if !reflect.DeepEqual(customers, expected) {
+		t.Errorf("Customer.Search returned %+v, expected %+v", customers, expected)
+	}
+}
+
+func TestCustomerSearchWithoutOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"query": "country:Canada"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/search.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"customers": [{"id":207119551}]}`))
+
+	customers, err := client.Customer.Search(context.Background(), "country:Canada", nil)
+	if err != nil {
+		t.Errorf("Customer.Search returned error: %v", err)
+	}
+
+	expected := []Customer{{ID: 207119551}}
+	if !reflect.DeepEqual(customers, expected) {
+		t.Errorf("Customer.Search returned %+v, expected %+v", customers, expected)
+	}
+}
+
+func TestCustomerListOrders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"status": "any"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/orders.json", client.pathPrefix),
+		params,
+		httpmock.NewBytesResponder(200, loadFixture("orders.json")))
+
+	orders, err := client.Customer.ListOrders(context.Background(), 207119551, OrderListOptions{Status: OrderStatusAny})
+	if err != nil {
+		t.Fatalf("Customer.ListOrders returned error: %v", err)
+	}
+
+	if len(orders) != 2 {
+		t.Fatalf("Customer.ListOrders returned %d orders, expected 2", len(orders))
+	}
+
+	if orders[0].ID != 450789469 || orders[0].Name != "#1001" {
+		t.Errorf("Customer.ListOrders returned %+v", orders[0])
+	}
+}
+
+func TestCustomerSendInvite(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/send_invite.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(201, `{"customer_invite": {"to":"bob.norman@mail.example.com","from":"steve@apple.com","bcc":[],"subject":"Welcome to my new shop","custom_message":"My awesome new store"}}`), nil
+		})
+
+	invite := CustomerInvite{
+		Subject:       "Welcome to my new shop",
+		CustomMessage: "My awesome new store",
+	}
+
+	returnedInvite, err := client.Customer.SendInvite(context.Background(), 207119551, invite)
+	if err != nil {
+		t.Fatalf("Customer.SendInvite returned error: %v", err)
+	}
+
+	expected := &CustomerInvite{
+		To:            "bob.norman@mail.example.com",
+		From:          "steve@apple.com",
+		Bcc:           []string{},
+		Subject:       "Welcome to my new shop",
+		CustomMessage: "My awesome new store",
+	}
+	if !reflect.DeepEqual(returnedInvite, expected) {
+		t.Errorf("Customer.SendInvite returned %+v, expected %+v", returnedInvite, expected)
+	}
+
+	expectedSent := map[string]interface{}{"subject": "Welcome to my new shop", "custom_message": "My awesome new store"}
+	if !reflect.DeepEqual(sent["customer_invite"], expectedSent) {
+		t.Errorf("Customer.SendInvite sent %+v, expected %+v", sent["customer_invite"], expectedSent)
+	}
+}
+
+func TestCustomerAccountActivationURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expected := "https://fooshop.myshopify.com/account/activate/207119551/86688abf23572680740b1c062fa37826-1458248300"
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/207119551/account_activation_url.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, fmt.Sprintf(`{"account_activation_url": "%s"}`, expected)))
+
+	url, err := client.Customer.AccountActivationURL(context.Background(), 207119551)
+	if err != nil {
+		t.Fatalf("Customer.AccountActivationURL returned error: %v", err)
+	}
+
+	if url != expected {
+		t.Errorf("Customer.AccountActivationURL returned %s, expected %s", url, expected)
+	}
+}
+
+func TestCustomerMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1/metafields.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":1},{"id":2}]}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1/metafields.json", client.pathPrefix),
+		httpmock.NewBytesResponder(201, loadFixture("metafield.json")))
+
+	metafields, err := client.Customer.ListMetafields(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("Customer.ListMetafields() returned error: %v", err)
+	}
+
+	expected := []Metafield{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Customer.ListMetafields() returned %+v, expected %+v", metafields, expected)
+	}
+
+	metafield, err := client.Customer.CreateMetafield(context.Background(), 1, Metafield{Namespace: "affiliates", Key: "app_key"})
+	if err != nil {
+		t.Fatalf("Customer.CreateMetafield() returned error: %v", err)
+	}
+
+	metafieldTests(t, *metafield)
+}
@@ -0,0 +1,233 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Owner resources of fulfillments, see Client.NewFulfillmentService
+const (
+	FulfillmentOwnerOrders            = "orders"
+	FulfillmentOwnerFulfillmentOrders = "fulfillment_orders"
+)
+
+// FulfillmentService is an interface for interfacing with the fulfillment
+// endpoints of the Shopify API. It is scoped to an order or a fulfillment
+// order, see Client.NewFulfillmentService, or to the shop when used as
+// Client.Fulfillment.
+//
+// List needs an order or a fulfillment order scope, Count, Get, Update,
+// Complete and Transition need an order scope. Create and Cancel work with
+// an order scope or none, the latter for fulfillments of fulfillment orders.
+// UpdateTracking works with any scope.
+// See: https://help.shopify.com/api/reference/fulfillment
+type FulfillmentService interface {
+	List(context.Context, interface{}) ([]Fulfillment, error)
+	Count(context.Context, interface{}) (int, error)
+	Get(context.Context, int64, interface{}) (*Fulfillment, error)
+	Create(context.Context, Fulfillment) (*Fulfillment, error)
+	Update(context.Context, Fulfillment) (*Fulfillment, error)
+	Complete(context.Context, int64) (*Fulfillment, error)
+	Transition(context.Context, int64) (*Fulfillment, error)
+	Cancel(context.Context, int64) (*Fulfillment, error)
+	UpdateTracking(context.Context, int64, FulfillmentTrackingInfo, bool) (*Fulfillment, error)
+}
+
+// FulfillmentsService is an interface for other Shopify resources
+// to interface with the fulfillment endpoints of the Shopify API.
+// See: https://help.shopify.com/api/reference/fulfillment
+type FulfillmentsService interface {
+	ListFulfillments(context.Context, int64, interface{}) ([]Fulfillment, error)
+	CountFulfillments(context.Context, int64, interface{}) (int, error)
+	GetFulfillment(context.Context, int64, int64, interface{}) (*Fulfillment, error)
+	CreateFulfillment(context.Context, int64, Fulfillment) (*Fulfillment, error)
+	UpdateFulfillment(context.Context, int64, Fulfillment) (*Fulfillment, error)
+	CompleteFulfillment(context.Context, int64, int64) (*Fulfillment, error)
+	TransitionFulfillment(context.Context, int64, int64) (*Fulfillment, error)
+	CancelFulfillment(context.Context, int64, int64) (*Fulfillment, error)
+	UpdateFulfillmentTracking(context.Context, int64, int64, FulfillmentTrackingInfo, bool) (*Fulfillment, error)
+}
+
+// FulfillmentServiceOp handles communication with the fulfillment
+// related methods of the Shopify API.
+type FulfillmentServiceOp struct {
+	client     *Client
+	resource   string
+	resourceID int64
+}
+
+// FulfillmentStatus is the status of a fulfillment
+type FulfillmentStatus string
+
+const (
+	FulfillmentStatusPending   FulfillmentStatus = "pending"
+	FulfillmentStatusOpen      FulfillmentStatus = "open"
+	FulfillmentStatusSuccess   FulfillmentStatus = "success"
+	FulfillmentStatusCancelled FulfillmentStatus = "cancelled"
+	FulfillmentStatusError     FulfillmentStatus = "error"
+	FulfillmentStatusFailure   FulfillmentStatus = "failure"
+)
+
+// Fulfillment represents a Shopify fulfillment.
+type Fulfillment struct {
+	ID                int64             `json:"id,omitempty"`
+	OrderID           int64             `json:"order_id,omitempty"`
+	LocationID        int64             `json:"location_id,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	Status            FulfillmentStatus `json:"status,omitempty"`
+	ShipmentStatus    string            `json:"shipment_status,omitempty"`
+	Service           string            `json:"service,omitempty"`
+	CreatedAt         *time.Time        `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time        `json:"updated_at,omitempty"`
+	TrackingCompany   string            `json:"tracking_company,omitempty"`
+	TrackingNumber    string            `json:"tracking_number,omitempty"`
+	TrackingNumbers   []string          `json:"tracking_numbers,omitempty"`
+	TrackingURL       string            `json:"tracking_url,omitempty"`
+	TrackingURLs      []string          `json:"tracking_urls,omitempty"`
+	Receipt           *Receipt          `json:"receipt,omitempty"`
+	LineItems         []LineItem        `json:"line_items,omitempty"`
+	NotifyCustomer    bool              `json:"notify_customer,omitempty"`
+	AdminGraphqlAPIID string            `json:"admin_graphql_api_id,omitempty"`
+
+	// TrackingInfo and LineItemsByFulfillmentOrder are used to create a
+	// fulfillment from fulfillment orders
+	TrackingInfo                *FulfillmentTrackingInfo                 `json:"tracking_info,omitempty"`
+	LineItemsByFulfillmentOrder []FulfillmentLineItemsByFulfillmentOrder `json:"line_items_by_fulfillment_order,omitempty"`
+}
+
+// Receipt represents a Shopify receipt.
+type Receipt struct {
+	TestCase      bool   `json:"testcase,omitempty"`
+	Authorization string `json:"authorization,omitempty"`
+}
+
+// FulfillmentTrackingInfo is the tracking information of a fulfillment
+type FulfillmentTrackingInfo struct {
+	Company string `json:"company,omitempty"`
+	Number  string `json:"number,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// FulfillmentLineItemsByFulfillmentOrder are the line items of a fulfillment
+// order to fulfill. All of them are fulfilled if FulfillmentOrderLineItems is
+// empty.
+type FulfillmentLineItemsByFulfillmentOrder struct {
+	FulfillmentOrderID        int64                              `json:"fulfillment_order_id"`
+	FulfillmentOrderLineItems []FulfillmentOrderLineItemQuantity `json:"fulfillment_order_line_items,omitempty"`
+}
+
+// FulfillmentOrderLineItemQuantity is the quantity of a fulfillment order
+// line item
+type FulfillmentOrderLineItemQuantity struct {
+	ID       int64 `json:"id"`
+	Quantity int   `json:"quantity"`
+}
+
+// FulfillmentResource represents the result from the fulfillments/X.json endpoint
+type FulfillmentResource struct {
+	Fulfillment *Fulfillment `json:"fulfillment"`
+}
+
+// FulfillmentsResource represents the result from the fulfillments.json endpoint
+type FulfillmentsResource struct {
+	Fulfillments []Fulfillment `json:"fulfillments"`
+}
+
+// fulfillmentTrackingUpdate is the body sent to the update_tracking.json endpoint
+type fulfillmentTrackingUpdate struct {
+	Fulfillment struct {
+		NotifyCustomer bool                    `json:"notify_customer"`
+		TrackingInfo   FulfillmentTrackingInfo `json:"tracking_info"`
+	} `json:"fulfillment"`
+}
+
+// List fulfillments
+func (s *FulfillmentServiceOp) List(ctx context.Context, options interface{}) ([]Fulfillment, error) {
+	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s.json", prefix)
+	resource := new(FulfillmentsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Fulfillments, err
+}
+
+// Count fulfillments
+func (s *FulfillmentServiceOp) Count(ctx context.Context, options interface{}) (int, error) {
+	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/count.json", prefix)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual fulfillment
+func (s *FulfillmentServiceOp) Get(ctx context.Context, fulfillmentID int64, options interface{}) (*Fulfillment, error) {
+	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%d.json", prefix, fulfillmentID)
+	resource := new(FulfillmentResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Fulfillment, err
+}
+
+// Create a new fulfillment
+func (s *FulfillmentServiceOp) Create(ctx context.Context, fulfillment Fulfillment) (*Fulfillment, error) {
+	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s.json", prefix)
+	wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
+	resource := new(FulfillmentResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Fulfillment, err
+}
+
+// Update an existing fulfillment
+func (s *FulfillmentServiceOp) Update(ctx context.Context, fulfillment Fulfillment) (*Fulfillment, error) {
+	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%d.json", prefix, fulfillment.ID)
+	wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
+	resource := new(FulfillmentResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Fulfillment, err
+}
+
+// Complete an existing fulfillment
+func (s *FulfillmentServiceOp) Complete(ctx context.Context, fulfillmentID int64) (*Fulfillment, error) {
+	return s.action(ctx, fulfillmentID, "complete")
+}
+
+// Transition an existing fulfillment to open
+func (s *FulfillmentServiceOp) Transition(ctx context.Context, fulfillmentID int64) (*Fulfillment, error) {
+	return s.action(ctx, fulfillmentID, "open")
+}
+
+// Cancel an existing fulfillment
+func (s *FulfillmentServiceOp) Cancel(ctx context.Context, fulfillmentID int64) (*Fulfillment, error) {
+	return s.action(ctx, fulfillmentID, "cancel")
+}
+
+// UpdateTracking replaces the tracking information of an existing
+// fulfillment, notifyCustomer sends the new tracking information to the
+// customer. The endpoint isn't nested under an order so the scope of the
+// service doesn't matter.
+func (s *FulfillmentServiceOp) UpdateTracking(ctx context.Context, fulfillmentID int64, info FulfillmentTrackingInfo, notifyCustomer bool) (*Fulfillment, error) {
+	prefix := FulfillmentPathPrefix("", 0)
+	path := fmt.Sprintf("%s/%d/update_tracking.json", prefix, fulfillmentID)
+	wrappedData := fulfillmentTrackingUpdate{}
+	wrappedData.Fulfillment.NotifyCustomer = notifyCustomer
+	wrappedData.Fulfillment.TrackingInfo = info
+	resource := new(FulfillmentResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Fulfillment, err
+}
+
+// action posts to the endpoint of a fulfillment status change, e.g. cancel
+func (s *FulfillmentServiceOp) action(ctx context.Context, fulfillmentID int64, action string) (*Fulfillment, error) {
+	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%d/%s.json", prefix, fulfillmentID, action)
+	resource := new(FulfillmentResource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
+	return resource.Fulfillment, err
+}
+
+// NewFulfillmentService returns a FulfillmentService for the fulfillments of
+// the given owner, e.g. NewFulfillmentService(FulfillmentOwnerOrders, id).
+func (c *Client) NewFulfillmentService(resource string, resourceID int64) FulfillmentService {
+	return &FulfillmentServiceOp{client: c, resource: resource, resourceID: resourceID}
+}
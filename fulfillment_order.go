@@ -0,0 +1,362 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const fulfillmentOrdersBasePath = "fulfillment_orders"
+
+// FulfillmentOrderService is an interface for interfacing with the
+// fulfillment order endpoints of the Shopify API.
+// See: https://shopify.dev/api/admin-rest/latest/resources/fulfillmentorder
+type FulfillmentOrderService interface {
+	List(context.Context, int64, interface{}) ([]FulfillmentOrder, error)
+	Get(context.Context, int64, interface{}) (*FulfillmentOrder, error)
+	Move(context.Context, int64, FulfillmentOrderMoveRequest) (*FulfillmentOrderMoveResult, error)
+	Cancel(context.Context, int64) (*FulfillmentOrderCancelResult, error)
+	Close(context.Context, int64, string) (*FulfillmentOrder, error)
+	Hold(context.Context, int64, FulfillmentOrderHoldRequest) (*FulfillmentOrder, error)
+	ReleaseHold(context.Context, int64) (*FulfillmentOrder, error)
+	Reschedule(context.Context, int64, time.Time) (*FulfillmentOrder, error)
+	ListFulfillments(context.Context, int64, interface{}) ([]Fulfillment, error)
+
+	// Fulfillment and cancellation requests between the merchant and the
+	// fulfillment service of the assigned location
+	SendFulfillmentRequest(context.Context, int64, FulfillmentRequest) (*FulfillmentRequestResult, error)
+	AcceptFulfillmentRequest(context.Context, int64, string) (*FulfillmentOrder, error)
+	RejectFulfillmentRequest(context.Context, int64, string) (*FulfillmentOrder, error)
+	SendCancellationRequest(context.Context, int64, string) (*FulfillmentOrder, error)
+	AcceptCancellationRequest(context.Context, int64, string) (*FulfillmentOrder, error)
+	RejectCancellationRequest(context.Context, int64, string) (*FulfillmentOrder, error)
+}
+
+// FulfillmentOrderServiceOp handles communication with the fulfillment order
+// related methods of the Shopify API.
+type FulfillmentOrderServiceOp struct {
+	client *Client
+}
+
+// FulfillmentOrderStatus is the status of a fulfillment order
+type FulfillmentOrderStatus string
+
+const (
+	FulfillmentOrderStatusOpen       FulfillmentOrderStatus = "open"
+	FulfillmentOrderStatusInProgress FulfillmentOrderStatus = "in_progress"
+	FulfillmentOrderStatusScheduled  FulfillmentOrderStatus = "scheduled"
+	FulfillmentOrderStatusOnHold     FulfillmentOrderStatus = "on_hold"
+	FulfillmentOrderStatusIncomplete FulfillmentOrderStatus = "incomplete"
+	FulfillmentOrderStatusCancelled  FulfillmentOrderStatus = "cancelled"
+	FulfillmentOrderStatusClosed     FulfillmentOrderStatus = "closed"
+)
+
+// FulfillmentOrderRequestStatus is the status of the requests made to the
+// fulfillment service of a fulfillment order
+type FulfillmentOrderRequestStatus string
+
+const (
+	FulfillmentOrderRequestStatusUnsubmitted           FulfillmentOrderRequestStatus = "unsubmitted"
+	FulfillmentOrderRequestStatusSubmitted             FulfillmentOrderRequestStatus = "submitted"
+	FulfillmentOrderRequestStatusAccepted              FulfillmentOrderRequestStatus = "accepted"
+	FulfillmentOrderRequestStatusRejected              FulfillmentOrderRequestStatus = "rejected"
+	FulfillmentOrderRequestStatusCancellationRequested FulfillmentOrderRequestStatus = "cancellation_requested"
+	FulfillmentOrderRequestStatusCancellationAccepted  FulfillmentOrderRequestStatus = "cancellation_accepted"
+	FulfillmentOrderRequestStatusCancellationRejected  FulfillmentOrderRequestStatus = "cancellation_rejected"
+	FulfillmentOrderRequestStatusClosed                FulfillmentOrderRequestStatus = "closed"
+)
+
+// FulfillmentOrderHoldReason is the reason a fulfillment order is on hold
+type FulfillmentOrderHoldReason string
+
+const (
+	FulfillmentOrderHoldReasonAwaitingPayment     FulfillmentOrderHoldReason = "awaiting_payment"
+	FulfillmentOrderHoldReasonHighRiskOfFraud     FulfillmentOrderHoldReason = "high_risk_of_fraud"
+	FulfillmentOrderHoldReasonIncorrectAddress    FulfillmentOrderHoldReason = "incorrect_address"
+	FulfillmentOrderHoldReasonInventoryOutOfStock FulfillmentOrderHoldReason = "inventory_out_of_stock"
+	FulfillmentOrderHoldReasonOther               FulfillmentOrderHoldReason = "other"
+)
+
+// FulfillmentOrderMerchantRequestKind is the kind of a merchant request
+type FulfillmentOrderMerchantRequestKind string
+
+const (
+	FulfillmentOrderMerchantRequestKindFulfillmentRequest   FulfillmentOrderMerchantRequestKind = "fulfillment_request"
+	FulfillmentOrderMerchantRequestKindCancellationRequest  FulfillmentOrderMerchantRequestKind = "cancellation_request"
+	FulfillmentOrderMerchantRequestKindLegacyFulfillRequest FulfillmentOrderMerchantRequestKind = "legacy_fulfill_request"
+)
+
+// FulfillmentOrder represents a group of line items of an order to be
+// fulfilled from the same location
+type FulfillmentOrder struct {
+	ID                 int64                             `json:"id,omitempty"`
+	ShopID             int64                             `json:"shop_id,omitempty"`
+	OrderID            int64                             `json:"order_id,omitempty"`
+	AssignedLocationID int64                             `json:"assigned_location_id,omitempty"`
+	Status             FulfillmentOrderStatus            `json:"status,omitempty"`
+	RequestStatus      FulfillmentOrderRequestStatus     `json:"request_status,omitempty"`
+	SupportedActions   []string                          `json:"supported_actions,omitempty"`
+	LineItems          []FulfillmentOrderLineItem        `json:"line_items,omitempty"`
+	Destination        *FulfillmentOrderDestination      `json:"destination,omitempty"`
+	AssignedLocation   *FulfillmentOrderAssignedLocation `json:"assigned_location,omitempty"`
+	DeliveryMethod     *FulfillmentOrderDeliveryMethod   `json:"delivery_method,omitempty"`
+	FulfillmentHolds   []FulfillmentOrderHold            `json:"fulfillment_holds,omitempty"`
+	MerchantRequests   []FulfillmentOrderMerchantRequest `json:"merchant_requests,omitempty"`
+	FulfillAt          *time.Time                        `json:"fulfill_at,omitempty"`
+	FulfillBy          *time.Time                        `json:"fulfill_by,omitempty"`
+	CreatedAt          *time.Time                        `json:"created_at,omitempty"`
+	UpdatedAt          *time.Time                        `json:"updated_at,omitempty"`
+}
+
+// FulfillmentOrderLineItem represents a line item of a fulfillment order
+type FulfillmentOrderLineItem struct {
+	ID                  int64 `json:"id,omitempty"`
+	ShopID              int64 `json:"shop_id,omitempty"`
+	FulfillmentOrderID  int64 `json:"fulfillment_order_id,omitempty"`
+	LineItemID          int64 `json:"line_item_id,omitempty"`
+	InventoryItemID     int64 `json:"inventory_item_id,omitempty"`
+	VariantID           int64 `json:"variant_id,omitempty"`
+	Quantity            int   `json:"quantity,omitempty"`
+	FulfillableQuantity int   `json:"fulfillable_quantity,omitempty"`
+}
+
+// FulfillmentOrderDestination is where the items of a fulfillment order are
+// shipped to
+type FulfillmentOrderDestination struct {
+	ID        int64  `json:"id,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Company   string `json:"company,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	Address1  string `json:"address1,omitempty"`
+	Address2  string `json:"address2,omitempty"`
+	City      string `json:"city,omitempty"`
+	Province  string `json:"province,omitempty"`
+	Country   string `json:"country,omitempty"`
+	Zip       string `json:"zip,omitempty"`
+}
+
+// FulfillmentOrderAssignedLocation is the location the items of a fulfillment
+// order are fulfilled from
+type FulfillmentOrderAssignedLocation struct {
+	LocationID  int64  `json:"location_id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+	Address1    string `json:"address1,omitempty"`
+	Address2    string `json:"address2,omitempty"`
+	City        string `json:"city,omitempty"`
+	Province    string `json:"province,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	Zip         string `json:"zip,omitempty"`
+}
+
+// FulfillmentOrderDeliveryMethod is how the items of a fulfillment order are
+// delivered, e.g. shipping or local pick up
+type FulfillmentOrderDeliveryMethod struct {
+	ID                  int64      `json:"id,omitempty"`
+	MethodType          string     `json:"method_type,omitempty"`
+	MinDeliveryDateTime *time.Time `json:"min_delivery_date_time,omitempty"`
+	MaxDeliveryDateTime *time.Time `json:"max_delivery_date_time,omitempty"`
+}
+
+// FulfillmentOrderHold is the reason a fulfillment order is on hold
+type FulfillmentOrderHold struct {
+	Reason      FulfillmentOrderHoldReason `json:"reason,omitempty"`
+	ReasonNotes string                     `json:"reason_notes,omitempty"`
+}
+
+// FulfillmentOrderMerchantRequest is a request sent by the merchant to the
+// fulfillment service of a fulfillment order
+type FulfillmentOrderMerchantRequest struct {
+	Kind           FulfillmentOrderMerchantRequestKind `json:"kind,omitempty"`
+	Message        string                              `json:"message,omitempty"`
+	RequestOptions map[string]interface{}              `json:"request_options,omitempty"`
+}
+
+// FulfillmentOrderMoveRequest moves a fulfillment order, or some of its line
+// items, to a new location
+type FulfillmentOrderMoveRequest struct {
+	NewLocationID int64                              `json:"new_location_id"`
+	LineItems     []FulfillmentOrderLineItemQuantity `json:"fulfillment_order_line_items,omitempty"`
+}
+
+// FulfillmentOrderHoldRequest puts a fulfillment order, or some of its line
+// items, on hold
+type FulfillmentOrderHoldRequest struct {
+	Reason         FulfillmentOrderHoldReason         `json:"reason"`
+	ReasonNotes    string                             `json:"reason_notes,omitempty"`
+	NotifyMerchant bool                               `json:"notify_merchant,omitempty"`
+	LineItems      []FulfillmentOrderLineItemQuantity `json:"fulfillment_order_line_items,omitempty"`
+}
+
+// FulfillmentRequest asks the fulfillment service to fulfill a fulfillment
+// order, or some of its line items
+type FulfillmentRequest struct {
+	Message        string                             `json:"message,omitempty"`
+	NotifyCustomer bool                               `json:"notify_customer,omitempty"`
+	LineItems      []FulfillmentOrderLineItemQuantity `json:"fulfillment_order_line_items,omitempty"`
+}
+
+// FulfillmentOrderMoveResult is the result of moving a fulfillment order
+type FulfillmentOrderMoveResult struct {
+	OriginalFulfillmentOrder  *FulfillmentOrder `json:"original_fulfillment_order"`
+	MovedFulfillmentOrder     *FulfillmentOrder `json:"moved_fulfillment_order"`
+	RemainingFulfillmentOrder *FulfillmentOrder `json:"remaining_fulfillment_order"`
+}
+
+// FulfillmentOrderCancelResult is the result of cancelling a fulfillment order
+type FulfillmentOrderCancelResult struct {
+	FulfillmentOrder            *FulfillmentOrder `json:"fulfillment_order"`
+	ReplacementFulfillmentOrder *FulfillmentOrder `json:"replacement_fulfillment_order"`
+}
+
+// FulfillmentRequestResult is the result of sending a fulfillment request.
+// Line items left out of the request are split into the unsubmitted
+// fulfillment order.
+type FulfillmentRequestResult struct {
+	OriginalFulfillmentOrder    *FulfillmentOrder `json:"original_fulfillment_order"`
+	SubmittedFulfillmentOrder   *FulfillmentOrder `json:"submitted_fulfillment_order"`
+	UnsubmittedFulfillmentOrder *FulfillmentOrder `json:"unsubmitted_fulfillment_order"`
+}
+
+// FulfillmentOrderResource represents the result from the fulfillment_orders/X.json endpoint
+type FulfillmentOrderResource struct {
+	FulfillmentOrder *FulfillmentOrder `json:"fulfillment_order"`
+}
+
+// FulfillmentOrdersResource represents the result from the orders/X/fulfillment_orders.json endpoint
+type FulfillmentOrdersResource struct {
+	FulfillmentOrders []FulfillmentOrder `json:"fulfillment_orders"`
+}
+
+// fulfillmentOrderMessage is the body of the requests only sending a message
+type fulfillmentOrderMessage struct {
+	Message string `json:"message,omitempty"`
+}
+
+// List fulfillment orders of an order
+func (s *FulfillmentOrderServiceOp) List(ctx context.Context, orderID int64, options interface{}) ([]FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/%s.json", ordersBasePath, orderID, fulfillmentOrdersBasePath)
+	resource := new(FulfillmentOrdersResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.FulfillmentOrders, err
+}
+
+// Get individual fulfillment order
+func (s *FulfillmentOrderServiceOp) Get(ctx context.Context, fulfillmentOrderID int64, options interface{}) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	resource := new(FulfillmentOrderResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.FulfillmentOrder, err
+}
+
+// Move a fulfillment order to a new location. Moving only some of the line
+// items splits them into the moved fulfillment order of the result.
+func (s *FulfillmentOrderServiceOp) Move(ctx context.Context, fulfillmentOrderID int64, move FulfillmentOrderMoveRequest) (*FulfillmentOrderMoveResult, error) {
+	path := fmt.Sprintf("%s/%d/move.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{"fulfillment_order": move}
+	resource := new(FulfillmentOrderMoveResult)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource, err
+}
+
+// Cancel a fulfillment order. The line items are assigned to the replacement
+// fulfillment order of the result.
+func (s *FulfillmentOrderServiceOp) Cancel(ctx context.Context, fulfillmentOrderID int64) (*FulfillmentOrderCancelResult, error) {
+	path := fmt.Sprintf("%s/%d/cancel.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	resource := new(FulfillmentOrderCancelResult)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
+	return resource, err
+}
+
+// Close a fulfillment order as incomplete, used by fulfillment services
+func (s *FulfillmentOrderServiceOp) Close(ctx context.Context, fulfillmentOrderID int64, message string) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/close.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{"fulfillment_order": fulfillmentOrderMessage{Message: message}}
+	return s.post(ctx, path, wrappedData)
+}
+
+// Hold a fulfillment order
+func (s *FulfillmentOrderServiceOp) Hold(ctx context.Context, fulfillmentOrderID int64, hold FulfillmentOrderHoldRequest) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/hold.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{"fulfillment_hold": hold}
+	return s.post(ctx, path, wrappedData)
+}
+
+// ReleaseHold releases a fulfillment order on hold
+func (s *FulfillmentOrderServiceOp) ReleaseHold(ctx context.Context, fulfillmentOrderID int64) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/release_hold.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	return s.post(ctx, path, nil)
+}
+
+// Reschedule a scheduled fulfillment order
+func (s *FulfillmentOrderServiceOp) Reschedule(ctx context.Context, fulfillmentOrderID int64, newFulfillAt time.Time) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/reschedule.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{
+		"fulfillment_order": map[string]interface{}{"new_fulfill_at": newFulfillAt},
+	}
+	return s.post(ctx, path, wrappedData)
+}
+
+// ListFulfillments of a fulfillment order
+func (s *FulfillmentOrderServiceOp) ListFulfillments(ctx context.Context, fulfillmentOrderID int64, options interface{}) ([]Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: fulfillmentOrdersBasePath, resourceID: fulfillmentOrderID}
+	return fulfillmentService.List(ctx, options)
+}
+
+// SendFulfillmentRequest sends a fulfillment request to the fulfillment
+// service of a fulfillment order
+func (s *FulfillmentOrderServiceOp) SendFulfillmentRequest(ctx context.Context, fulfillmentOrderID int64, request FulfillmentRequest) (*FulfillmentRequestResult, error) {
+	path := fmt.Sprintf("%s/%d/fulfillment_request.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{"fulfillment_request": request}
+	resource := new(FulfillmentRequestResult)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource, err
+}
+
+// AcceptFulfillmentRequest accepts a fulfillment request, used by fulfillment
+// services
+func (s *FulfillmentOrderServiceOp) AcceptFulfillmentRequest(ctx context.Context, fulfillmentOrderID int64, message string) (*FulfillmentOrder, error) {
+	return s.respond(ctx, fulfillmentOrderID, "fulfillment_request", "accept", message)
+}
+
+// RejectFulfillmentRequest rejects a fulfillment request, used by fulfillment
+// services
+func (s *FulfillmentOrderServiceOp) RejectFulfillmentRequest(ctx context.Context, fulfillmentOrderID int64, message string) (*FulfillmentOrder, error) {
+	return s.respond(ctx, fulfillmentOrderID, "fulfillment_request", "reject", message)
+}
+
+// SendCancellationRequest asks the fulfillment service of a fulfillment order
+// to cancel it
+func (s *FulfillmentOrderServiceOp) SendCancellationRequest(ctx context.Context, fulfillmentOrderID int64, message string) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/cancellation_request.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{"cancellation_request": fulfillmentOrderMessage{Message: message}}
+	return s.post(ctx, path, wrappedData)
+}
+
+// AcceptCancellationRequest accepts a cancellation request, used by
+// fulfillment services
+func (s *FulfillmentOrderServiceOp) AcceptCancellationRequest(ctx context.Context, fulfillmentOrderID int64, message string) (*FulfillmentOrder, error) {
+	return s.respond(ctx, fulfillmentOrderID, "cancellation_request", "accept", message)
+}
+
+// RejectCancellationRequest rejects a cancellation request, used by
+// fulfillment services
+func (s *FulfillmentOrderServiceOp) RejectCancellationRequest(ctx context.Context, fulfillmentOrderID int64, message string) (*FulfillmentOrder, error) {
+	return s.respond(ctx, fulfillmentOrderID, "cancellation_request", "reject", message)
+}
+
+// respond accepts or rejects a fulfillment or cancellation request
+func (s *FulfillmentOrderServiceOp) respond(ctx context.Context, fulfillmentOrderID int64, request, action, message string) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/%s/%s.json", fulfillmentOrdersBasePath, fulfillmentOrderID, request, action)
+	wrappedData := map[string]interface{}{request: fulfillmentOrderMessage{Message: message}}
+	return s.post(ctx, path, wrappedData)
+}
+
+// post sends data to an endpoint returning the updated fulfillment order
+func (s *FulfillmentOrderServiceOp) post(ctx context.Context, path string, data interface{}) (*FulfillmentOrder, error) {
+	resource := new(FulfillmentOrderResource)
+	err := s.client.PostWithContext(ctx, path, data, resource)
+	return resource.FulfillmentOrder, err
+}
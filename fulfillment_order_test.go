@@ -0,0 +1,352 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func fulfillmentOrderTests(t *testing.T, fulfillmentOrder FulfillmentOrder) {
+	// Check that the ID is assigned to the returned fulfillment order
+	var expectedInt int64 = 1046000788
+	if fulfillmentOrder.ID != expectedInt {
+		t.Errorf("FulfillmentOrder.ID returned %+v, expected %+v", fulfillmentOrder.ID, expectedInt)
+	}
+
+	if fulfillmentOrder.OrderID != 450789469 || fulfillmentOrder.AssignedLocationID != 24826418 {
+		t.Errorf("FulfillmentOrder.OrderID/AssignedLocationID returned %d/%d", fulfillmentOrder.OrderID, fulfillmentOrder.AssignedLocationID)
+	}
+
+	if fulfillmentOrder.Status != FulfillmentOrderStatusOpen || fulfillmentOrder.RequestStatus != FulfillmentOrderRequestStatusUnsubmitted {
+		t.Errorf("FulfillmentOrder.Status/RequestStatus returned %s/%s", fulfillmentOrder.Status, fulfillmentOrder.RequestStatus)
+	}
+
+	expectedActions := []string{"create_fulfillment", "move", "hold"}
+	if !reflect.DeepEqual(fulfillmentOrder.SupportedActions, expectedActions) {
+		t.Errorf("FulfillmentOrder.SupportedActions returned %+v, expected %+v", fulfillmentOrder.SupportedActions, expectedActions)
+	}
+
+	expectedLineItems := []FulfillmentOrderLineItem{{
+		ID:                  1025578642,
+		ShopID:              548380009,
+		FulfillmentOrderID:  1046000788,
+		LineItemID:          466157049,
+		InventoryItemID:     39072856,
+		VariantID:           39072856,
+		Quantity:            1,
+		FulfillableQuantity: 1,
+	}}
+	if !reflect.DeepEqual(fulfillmentOrder.LineItems, expectedLineItems) {
+		t.Errorf("FulfillmentOrder.LineItems returned %+v, expected %+v", fulfillmentOrder.LineItems, expectedLineItems)
+	}
+
+	if fulfillmentOrder.Destination == nil || fulfillmentOrder.Destination.Email != "bob.norman@mail.example.com" {
+		t.Errorf("FulfillmentOrder.Destination returned %+v", fulfillmentOrder.Destination)
+	}
+
+	if fulfillmentOrder.AssignedLocation == nil || fulfillmentOrder.AssignedLocation.Name != "Apple Api Shipwire" {
+		t.Errorf("FulfillmentOrder.AssignedLocation returned %+v", fulfillmentOrder.AssignedLocation)
+	}
+
+	if fulfillmentOrder.DeliveryMethod == nil || fulfillmentOrder.DeliveryMethod.MethodType != "shipping" {
+		t.Errorf("FulfillmentOrder.DeliveryMethod returned %+v", fulfillmentOrder.DeliveryMethod)
+	}
+
+	expectedTime := time.Date(2021, time.October, 1, 20, 0, 0, 0, time.UTC)
+	if fulfillmentOrder.FulfillAt == nil || !fulfillmentOrder.FulfillAt.Equal(expectedTime) {
+		t.Errorf("FulfillmentOrder.FulfillAt returned %+v, expected %+v", fulfillmentOrder.FulfillAt, expectedTime)
+	}
+}
+
+// fulfillmentOrderResponder records the body of the request into sent and
+// responds with the fulfillment order fixture
+func fulfillmentOrderResponder(sent *map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		*sent = nil
+		b, _ := ioutil.ReadAll(req.Body)
+		if len(b) > 0 {
+			_ = json.Unmarshal(b, sent)
+		}
+		return httpmock.NewBytesResponse(200, loadFixture("fulfillment_order.json")), nil
+	}
+}
+
+func TestFulfillmentOrderList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillment_orders.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("fulfillment_orders.json")))
+
+	fulfillmentOrders, err := client.FulfillmentOrder.List(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.List returned error: %v", err)
+	}
+
+	if len(fulfillmentOrders) != 1 {
+		t.Fatalf("FulfillmentOrder.List returned %d fulfillment orders, expected 1", len(fulfillmentOrders))
+	}
+
+	fulfillmentOrderTests(t, fulfillmentOrders[0])
+}
+
+func TestFulfillmentOrderGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1046000788.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("fulfillment_order.json")))
+
+	fulfillmentOrder, err := client.FulfillmentOrder.Get(context.Background(), 1046000788, nil)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Get returned error: %v", err)
+	}
+
+	fulfillmentOrderTests(t, *fulfillmentOrder)
+}
+
+func TestFulfillmentOrderMove(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1046000788/move.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(200, `{
+				"original_fulfillment_order": {"id": 1046000788, "status": "closed"},
+				"moved_fulfillment_order": {"id": 1046000789, "status": "open", "assigned_location_id": 905684977},
+				"remaining_fulfillment_order": null
+			}`), nil
+		})
+
+	move := FulfillmentOrderMoveRequest{
+		NewLocationID: 905684977,
+		LineItems:     []FulfillmentOrderLineItemQuantity{{ID: 1025578642, Quantity: 1}},
+	}
+
+	result, err := client.FulfillmentOrder.Move(context.Background(), 1046000788, move)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Move returned error: %v", err)
+	}
+
+	expected := &FulfillmentOrderMoveResult{
+		OriginalFulfillmentOrder: &FulfillmentOrder{ID: 1046000788, Status: FulfillmentOrderStatusClosed},
+		MovedFulfillmentOrder:    &FulfillmentOrder{ID: 1046000789, Status: FulfillmentOrderStatusOpen, AssignedLocationID: 905684977},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("FulfillmentOrder.Move returned %+v, expected %+v", result, expected)
+	}
+
+	expectedSent := map[string]interface{}{
+		"fulfillment_order": map[string]interface{}{
+			"new_location_id": float64(905684977),
+			"fulfillment_order_line_items": []interface{}{
+				map[string]interface{}{"id": float64(1025578642), "quantity": float64(1)},
+			},
+		},
+	}
+	if !reflect.DeepEqual(sent, expectedSent) {
+		t.Errorf("FulfillmentOrder.Move sent %+v, expected %+v", sent, expectedSent)
+	}
+}
+
+func TestFulfillmentOrderCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1046000788/cancel.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{
+			"fulfillment_order": {"id": 1046000788, "status": "closed"},
+			"replacement_fulfillment_order": {"id": 1046000790, "status": "open"}
+		}`))
+
+	result, err := client.FulfillmentOrder.Cancel(context.Background(), 1046000788)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Cancel returned error: %v", err)
+	}
+
+	expected := &FulfillmentOrderCancelResult{
+		FulfillmentOrder:            &FulfillmentOrder{ID: 1046000788, Status: FulfillmentOrderStatusClosed},
+		ReplacementFulfillmentOrder: &FulfillmentOrder{ID: 1046000790, Status: FulfillmentOrderStatusOpen},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("FulfillmentOrder.Cancel returned %+v, expected %+v", result, expected)
+	}
+}
+
+func TestFulfillmentOrderActions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fulfillAt := time.Date(2021, time.November, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	cases := []struct {
+		path     string
+		call     func() (*FulfillmentOrder, error)
+		expected map[string]interface{}
+	}{
+		{
+			"close",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.Close(ctx, 1046000788, "Not enough inventory")
+			},
+			map[string]interface{}{"fulfillment_order": map[string]interface{}{"message": "Not enough inventory"}},
+		},
+		{
+			"hold",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.Hold(ctx, 1046000788, FulfillmentOrderHoldRequest{
+					Reason:         FulfillmentOrderHoldReasonInventoryOutOfStock,
+					ReasonNotes:    "Waiting on new shipment",
+					NotifyMerchant: true,
+				})
+			},
+			map[string]interface{}{"fulfillment_hold": map[string]interface{}{
+				"reason":          "inventory_out_of_stock",
+				"reason_notes":    "Waiting on new shipment",
+				"notify_merchant": true,
+			}},
+		},
+		{
+			"release_hold",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.ReleaseHold(ctx, 1046000788)
+			},
+			nil,
+		},
+		{
+			"reschedule",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.Reschedule(ctx, 1046000788, fulfillAt)
+			},
+			map[string]interface{}{"fulfillment_order": map[string]interface{}{"new_fulfill_at": "2021-11-01T12:00:00Z"}},
+		},
+		{
+			"fulfillment_request/accept",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.AcceptFulfillmentRequest(ctx, 1046000788, "We will start processing your fulfillment on the next business day.")
+			},
+			map[string]interface{}{"fulfillment_request": map[string]interface{}{"message": "We will start processing your fulfillment on the next business day."}},
+		},
+		{
+			"fulfillment_request/reject",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.RejectFulfillmentRequest(ctx, 1046000788, "Not enough inventory on hand to complete the work.")
+			},
+			map[string]interface{}{"fulfillment_request": map[string]interface{}{"message": "Not enough inventory on hand to complete the work."}},
+		},
+		{
+			"cancellation_request",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.SendCancellationRequest(ctx, 1046000788, "The customer changed their mind.")
+			},
+			map[string]interface{}{"cancellation_request": map[string]interface{}{"message": "The customer changed their mind."}},
+		},
+		{
+			"cancellation_request/accept",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.AcceptCancellationRequest(ctx, 1046000788, "We had not started any processing yet.")
+			},
+			map[string]interface{}{"cancellation_request": map[string]interface{}{"message": "We had not started any processing yet."}},
+		},
+		{
+			"cancellation_request/reject",
+			func() (*FulfillmentOrder, error) {
+				return client.FulfillmentOrder.RejectCancellationRequest(ctx, 1046000788, "We have already sent the shipment out.")
+			},
+			map[string]interface{}{"cancellation_request": map[string]interface{}{"message": "We have already sent the shipment out."}},
+		},
+	}
+
+	for _, c := range cases {
+		var sent map[string]interface{}
+		httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1046000788/%s.json", client.pathPrefix, c.path),
+			fulfillmentOrderResponder(&sent))
+
+		fulfillmentOrder, err := c.call()
+		if err != nil {
+			t.Fatalf("FulfillmentOrder %s returned error: %v", c.path, err)
+		}
+
+		fulfillmentOrderTests(t, *fulfillmentOrder)
+
+		if !reflect.DeepEqual(sent, c.expected) {
+			t.Errorf("FulfillmentOrder %s sent %+v, expected %+v", c.path, sent, c.expected)
+		}
+	}
+}
+
+func TestFulfillmentOrderSendFulfillmentRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1046000788/fulfillment_request.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(200, `{
+				"original_fulfillment_order": {"id": 1046000788, "request_status": "submitted"},
+				"submitted_fulfillment_order": {"id": 1046000788, "request_status": "submitted"},
+				"unsubmitted_fulfillment_order": null
+			}`), nil
+		})
+
+	request := FulfillmentRequest{
+		Message:   "Fulfill this ASAP please.",
+		LineItems: []FulfillmentOrderLineItemQuantity{{ID: 1025578642, Quantity: 1}},
+	}
+
+	result, err := client.FulfillmentOrder.SendFulfillmentRequest(context.Background(), 1046000788, request)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.SendFulfillmentRequest returned error: %v", err)
+	}
+
+	expected := &FulfillmentRequestResult{
+		OriginalFulfillmentOrder:  &FulfillmentOrder{ID: 1046000788, RequestStatus: FulfillmentOrderRequestStatusSubmitted},
+		SubmittedFulfillmentOrder: &FulfillmentOrder{ID: 1046000788, RequestStatus: FulfillmentOrderRequestStatusSubmitted},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("FulfillmentOrder.SendFulfillmentRequest returned %+v, expected %+v", result, expected)
+	}
+
+	expectedSent := map[string]interface{}{
+		"fulfillment_request": map[string]interface{}{
+			"message": "Fulfill this ASAP please.",
+			"fulfillment_order_line_items": []interface{}{
+				map[string]interface{}{"id": float64(1025578642), "quantity": float64(1)},
+			},
+		},
+	}
+	if !reflect.DeepEqual(sent, expectedSent) {
+		t.Errorf("FulfillmentOrder.SendFulfillmentRequest sent %+v, expected %+v", sent, expectedSent)
+	}
+}
+
+func TestFulfillmentOrderListFulfillments(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1046000788/fulfillments.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillments": [{"id":1},{"id":2}]}`))
+
+	fulfillments, err := client.FulfillmentOrder.ListFulfillments(context.Background(), 1046000788, nil)
+	if err != nil {
+		t.Errorf("FulfillmentOrder.ListFulfillments returned error: %v", err)
+	}
+
+	expected := []Fulfillment{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(fulfillments, expected) {
+		t.Errorf("FulfillmentOrder.ListFulfillments returned %+v, expected %+v", fulfillments, expected)
+	}
+}
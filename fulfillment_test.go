@@ -0,0 +1,326 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func fulfillmentTests(t *testing.T, fulfillment Fulfillment) {
+	// Check that the ID is assigned to the returned fulfillment
+	var expectedInt int64 = 255858046
+	if fulfillment.ID != expectedInt {
+		t.Errorf("Fulfillment.ID returned %+v, expected %+v", fulfillment.ID, expectedInt)
+	}
+
+	expectedInt = 450789469
+	if fulfillment.OrderID != expectedInt {
+		t.Errorf("Fulfillment.OrderID returned %+v, expected %+v", fulfillment.OrderID, expectedInt)
+	}
+
+	if fulfillment.Status != FulfillmentStatusSuccess {
+		t.Errorf("Fulfillment.Status returned %+v, expected %+v", fulfillment.Status, FulfillmentStatusSuccess)
+	}
+
+	expectedNumbers := []string{"1Z2345"}
+	if fulfillment.TrackingCompany != "UPS" || !reflect.DeepEqual(fulfillment.TrackingNumbers, expectedNumbers) {
+		t.Errorf("Fulfillment tracking returned %s %+v", fulfillment.TrackingCompany, fulfillment.TrackingNumbers)
+	}
+
+	expectedReceipt := &Receipt{TestCase: true, Authorization: "123456"}
+	if !reflect.DeepEqual(fulfillment.Receipt, expectedReceipt) {
+		t.Errorf("Fulfillment.Receipt returned %+v, expected %+v", fulfillment.Receipt, expectedReceipt)
+	}
+
+	if len(fulfillment.LineItems) != 1 || fulfillment.LineItems[0].ID != 466157049 || fulfillment.LineItems[0].FulfillmentStatus != OrderFulfillmentStatusFulfilled {
+		t.Errorf("Fulfillment.LineItems returned %+v", fulfillment.LineItems)
+	}
+}
+
+func TestFulfillmentList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/fulfillments.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillments": [{"id":1},{"id":2}]}`))
+
+	fulfillments, err := client.Order.ListFulfillments(context.Background(), 123, nil)
+	if err != nil {
+		t.Errorf("Order.ListFulfillments returned error: %v", err)
+	}
+
+	expected := []Fulfillment{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(fulfillments, expected) {
+		t.Errorf("Order.ListFulfillments returned %+v, expected %+v", fulfillments, expected)
+	}
+}
+
+func TestFulfillmentCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/fulfillments/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 3}`))
+
+	cnt, err := client.Order.CountFulfillments(context.Background(), 123, nil)
+	if err != nil {
+		t.Errorf("Order.CountFulfillments returned error: %v", err)
+	}
+
+	expected := 3
+	if cnt != expected {
+		t.Errorf("Order.CountFulfillments returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestFulfillmentGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillments/255858046.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("fulfillment.json")))
+
+	fulfillment, err := client.Order.GetFulfillment(context.Background(), 450789469, 255858046, nil)
+	if err != nil {
+		t.Fatalf("Order.GetFulfillment returned error: %v", err)
+	}
+
+	fulfillmentTests(t, *fulfillment)
+}
+
+func TestFulfillmentCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillments.json", client.pathPrefix),
+		httpmock.NewBytesResponder(201, loadFixture("fulfillment.json")))
+
+	fulfillment := Fulfillment{
+		LocationID:     655441491,
+		TrackingNumber: "1Z2345",
+		LineItems:      []LineItem{{ID: 466157049}},
+		NotifyCustomer: true,
+	}
+
+	returnedFulfillment, err := client.Order.CreateFulfillment(context.Background(), 450789469, fulfillment)
+	if err != nil {
+		t.Fatalf("Order.CreateFulfillment returned error: %v", err)
+	}
+
+	fulfillmentTests(t, *returnedFulfillment)
+}
+
+func TestFulfillmentCreateFromFulfillmentOrders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillments.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(201, loadFixture("fulfillment.json")), nil
+		})
+
+	fulfillment := Fulfillment{
+		NotifyCustomer: true,
+		TrackingInfo:   &FulfillmentTrackingInfo{Company: "UPS", Number: "1Z2345"},
+		LineItemsByFulfillmentOrder: []FulfillmentLineItemsByFulfillmentOrder{
+			{
+				FulfillmentOrderID:        1046000788,
+				FulfillmentOrderLineItems: []FulfillmentOrderLineItemQuantity{{ID: 1025578642, Quantity: 1}},
+			},
+		},
+	}
+
+	returnedFulfillment, err := client.Fulfillment.Create(context.Background(), fulfillment)
+	if err != nil {
+		t.Fatalf("Fulfillment.Create returned error: %v", err)
+	}
+
+	fulfillmentTests(t, *returnedFulfillment)
+
+	expected := map[string]interface{}{
+		"fulfillment": map[string]interface{}{
+			"notify_customer": true,
+			"tracking_info":   map[string]interface{}{"company": "UPS", "number": "1Z2345"},
+			"line_items_by_fulfillment_order": []interface{}{
+				map[string]interface{}{
+					"fulfillment_order_id": float64(1046000788),
+					"fulfillment_order_line_items": []interface{}{
+						map[string]interface{}{"id": float64(1025578642), "quantity": float64(1)},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("Fulfillment.Create sent %+v, expected %+v", sent, expected)
+	}
+}
+
+func TestFulfillmentUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillments/255858046.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("fulfillment.json")))
+
+	fulfillment := Fulfillment{
+		ID:             255858046,
+		TrackingNumber: "1Z2345",
+	}
+
+	returnedFulfillment, err := client.Order.UpdateFulfillment(context.Background(), 450789469, fulfillment)
+	if err != nil {
+		t.Fatalf("Order.UpdateFulfillment returned error: %v", err)
+	}
+
+	fulfillmentTests(t, *returnedFulfillment)
+}
+
+func TestFulfillmentActions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		action string
+		call   func() (*Fulfillment, error)
+	}{
+		{"complete", func() (*Fulfillment, error) {
+			return client.Order.CompleteFulfillment(context.Background(), 450789469, 255858046)
+		}},
+		{"open", func() (*Fulfillment, error) {
+			return client.Order.TransitionFulfillment(context.Background(), 450789469, 255858046)
+		}},
+		{"cancel", func() (*Fulfillment, error) {
+			return client.Order.CancelFulfillment(context.Background(), 450789469, 255858046)
+		}},
+	}
+
+	for _, c := range cases {
+		httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillments/255858046/%s.json", client.pathPrefix, c.action),
+			httpmock.NewBytesResponder(200, loadFixture("fulfillment.json")))
+
+		fulfillment, err := c.call()
+		if err != nil {
+			t.Fatalf("Fulfillment %s returned error: %v", c.action, err)
+		}
+
+		fulfillmentTests(t, *fulfillment)
+	}
+}
+
+func TestFulfillmentCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillments/255858046/cancel.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("fulfillment.json")))
+
+	fulfillment, err := client.Fulfillment.Cancel(context.Background(), 255858046)
+	if err != nil {
+		t.Fatalf("Fulfillment.Cancel returned error: %v", err)
+	}
+
+	fulfillmentTests(t, *fulfillment)
+}
+
+func TestFulfillmentUpdateTracking(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillments/255858046/update_tracking.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, loadFixture("fulfillment.json")), nil
+		})
+
+	info := FulfillmentTrackingInfo{
+		Company: "UPS",
+		Number:  "1Z2345",
+		URL:     "https://www.ups.com/WebTracking?loc=en_US&requester=ST&trackNums=1Z2345",
+	}
+
+	for _, notify := range []bool{true, false} {
+		fulfillment, err := client.Fulfillment.UpdateTracking(context.Background(), 255858046, info, notify)
+		if err != nil {
+			t.Fatalf("Fulfillment.UpdateTracking returned error: %v", err)
+		}
+
+		fulfillmentTests(t, *fulfillment)
+
+		expected := map[string]interface{}{
+			"fulfillment": map[string]interface{}{
+				"notify_customer": notify,
+				"tracking_info": map[string]interface{}{
+					"company": "UPS",
+					"number":  "1Z2345",
+					"url":     "https://www.ups.com/WebTracking?loc=en_US&requester=ST&trackNums=1Z2345",
+				},
+			},
+		}
+		if !reflect.DeepEqual(sent, expected) {
+			t.Errorf("Fulfillment.UpdateTracking sent %+v, expected %+v", sent, expected)
+		}
+	}
+}
+
+func TestNewFulfillmentService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		resource string
+		id       int64
+	}{
+		{FulfillmentOwnerOrders, 450789469},
+		{FulfillmentOwnerFulfillmentOrders, 1046000788},
+	}
+
+	for _, c := range cases {
+		httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/%s/%d/fulfillments.json", client.pathPrefix, c.resource, c.id),
+			httpmock.NewStringResponder(200, `{"fulfillments": [{"id":255858046}]}`))
+
+		fulfillments, err := client.NewFulfillmentService(c.resource, c.id).List(context.Background(), nil)
+		if err != nil {
+			t.Errorf("Fulfillment.List for %s returned error: %v", c.resource, err)
+		}
+
+		expected := []Fulfillment{{ID: 255858046}}
+		if !reflect.DeepEqual(fulfillments, expected) {
+			t.Errorf("Fulfillment.List for %s returned %+v, expected %+v", c.resource, fulfillments, expected)
+		}
+	}
+}
+
+func TestOrderUpdateFulfillmentTracking(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// the tracking endpoint isn't nested under the order
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillments/255858046/update_tracking.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("fulfillment.json")))
+
+	info := FulfillmentTrackingInfo{Company: "UPS", Number: "1Z2345"}
+
+	fulfillment, err := client.Order.UpdateFulfillmentTracking(context.Background(), 450789469, 255858046, info, true)
+	if err != nil {
+		t.Fatalf("Order.UpdateFulfillmentTracking returned error: %v", err)
+	}
+	fulfillmentTests(t, *fulfillment)
+
+	fulfillment, err = client.NewFulfillmentService(FulfillmentOwnerOrders, 450789469).UpdateTracking(context.Background(), 255858046, info, true)
+	if err != nil {
+		t.Fatalf("Fulfillment.UpdateTracking returned error: %v", err)
+	}
+	fulfillmentTests(t, *fulfillment)
+}
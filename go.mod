@@ -5,6 +5,7 @@ go 1.17
 require (
 	github.com/google/go-querystring v1.1.0
 	github.com/jarcoal/httpmock v1.0.8
+	github.com/shopspring/decimal v1.3.1
 )
 
 require github.com/google/go-cmp v0.5.6 // indirect
@@ -0,0 +1,226 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const graphQLPath = "graphql.json"
+
+// GraphQLService is an interface to interact with the graphql endpoint
+// of the Shopify API.
+// See: https://shopify.dev/api/admin-graphql
+type GraphQLService interface {
+	Query(ctx context.Context, query string, vars, resp interface{}) error
+	QueryWithExtensions(ctx context.Context, query string, vars, resp interface{}) (*GraphQLExtensions, error)
+	ThrottleStatus() GraphQLThrottleStatus
+}
+
+// GraphQLServiceOp handles communication with the graphql endpoint of
+// the Shopify API.
+type GraphQLServiceOp struct {
+	client *Client
+	bucket graphQLBucket
+}
+
+// graphQLRequest is the body sent to the graphql endpoint
+type graphQLRequest struct {
+	Query     string      `json:"query"`
+	Variables interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the result from the graphql endpoint
+type graphQLResponse struct {
+	Data       json.RawMessage    `json:"data"`
+	Errors     []GraphQLError     `json:"errors"`
+	Extensions *GraphQLExtensions `json:"extensions"`
+}
+
+// GraphQLExtensions holds the extensions field of a graphql response
+type GraphQLExtensions struct {
+	Cost *GraphQLCost `json:"cost"`
+}
+
+// GraphQLCost is the query cost Shopify reports in the extensions field
+// See: https://shopify.dev/api/usage/rate-limits#graphql-admin-api-rate-limits
+type GraphQLCost struct {
+	RequestedQueryCost int                   `json:"requestedQueryCost"`
+	ActualQueryCost    *int                  `json:"actualQueryCost"`
+	ThrottleStatus     GraphQLThrottleStatus `json:"throttleStatus"`
+}
+
+// GraphQLThrottleStatus is the state of the graphql cost bucket
+type GraphQLThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"`
+}
+
+// GraphQLErrorLocation is the position in the query a GraphQLError refers to
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLErrorExtensions holds the extensions field of a GraphQLError
+type GraphQLErrorExtensions struct {
+	Code          string `json:"code"`
+	Documentation string `json:"documentation"`
+}
+
+// GraphQLError is a single entry of the top level errors field of a graphql
+// response, e.g. a syntax error or a throttled query.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLErrorLocation `json:"locations"`
+	Path       []interface{}          `json:"path"`
+	Extensions GraphQLErrorExtensions `json:"extensions"`
+}
+
+// GraphQLErrors is returned when the errors field of a graphql response is
+// not empty. Any data in the response is still decoded.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return strings.Join(messages, ", ")
+}
+
+// HasCode reports whether any of the errors has the given extensions code,
+// e.g. "THROTTLED".
+func (e GraphQLErrors) HasCode(code string) bool {
+	for _, err := range e {
+		if err.Extensions.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// UserError is a validation error returned in the userErrors field of a
+// mutation payload.
+type UserError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+	Code    string   `json:"code"`
+}
+
+// UserErrors is returned when a mutation payload contains userErrors. Any
+// data in the response is still decoded.
+type UserErrors []UserError
+
+func (e UserErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		if len(err.Field) > 0 {
+			messages[i] = fmt.Sprintf("%s: %s", strings.Join(err.Field, "."), err.Message)
+		} else {
+			messages[i] = err.Message
+		}
+	}
+	sort.Strings(messages)
+	return strings.Join(messages, ", ")
+}
+
+// Query executes a graphql query or mutation with the given variables and
+// decodes the data field of the response into resp.
+func (s *GraphQLServiceOp) Query(ctx context.Context, query string, vars, resp interface{}) error {
+	_, err := s.QueryWithExtensions(ctx, query, vars, resp)
+	return err
+}
+
+// QueryWithExtensions is like Query but also returns the extensions field of
+// the response, which holds the query cost.
+// Queries wait for enough budget in the cost bucket before being sent and
+// queries failing with THROTTLED are retried, see ThrottleStatus.
+func (s *GraphQLServiceOp) QueryWithExtensions(ctx context.Context, query string, vars, resp interface{}) (*GraphQLExtensions, error) {
+	data := graphQLRequest{
+		Query:     query,
+		Variables: vars,
+	}
+
+	attempts := s.client.retries
+	if attempts < defaultGraphQLThrottleAttempts {
+		attempts = defaultGraphQLThrottleAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := s.bucket.wait(ctx, query); err != nil {
+			return nil, err
+		}
+
+		resource := new(graphQLResponse)
+		err := s.client.PostWithContext(ctx, graphQLPath, data, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		s.bucket.update(query, resource.Extensions)
+
+		err = resource.decode(resp)
+		if gqlErrs, ok := err.(GraphQLErrors); ok && gqlErrs.HasCode("THROTTLED") && attempt < attempts {
+			s.client.log.Debugf("graphql query throttled, retrying")
+			if resource.Extensions == nil || resource.Extensions.Cost == nil {
+				if err := sleepWithContext(ctx, defaultGraphQLThrottleWait); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		return resource.Extensions, err
+	}
+}
+
+// ThrottleStatus returns the state of the graphql cost bucket as reported by
+// the last response, with the budget restored since then.
+func (s *GraphQLServiceOp) ThrottleStatus() GraphQLThrottleStatus {
+	return s.bucket.throttleStatus()
+}
+
+// decode unmarshals the data field into resp and maps errors and userErrors
+// into GraphQLErrors and UserErrors.
+func (r *graphQLResponse) decode(resp interface{}) error {
+	if resp != nil && len(r.Data) > 0 && string(r.Data) != "null" {
+		if err := json.Unmarshal(r.Data, resp); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Errors) > 0 {
+		return GraphQLErrors(r.Errors)
+	}
+
+	return extractUserErrors(r.Data)
+}
+
+// extractUserErrors collects the userErrors of every mutation payload in data.
+func extractUserErrors(data json.RawMessage) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	var userErrors UserErrors
+	for _, field := range fields {
+		payload := struct {
+			UserErrors UserErrors `json:"userErrors"`
+		}{}
+
+		// query results do not have to be objects, those have no userErrors
+		if err := json.Unmarshal(field, &payload); err == nil {
+			userErrors = append(userErrors, payload.UserErrors...)
+		}
+	}
+
+	if len(userErrors) > 0 {
+		return userErrors
+	}
+	return nil
+}
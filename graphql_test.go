@@ -0,0 +1,147 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestGraphQLQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent graphQLRequest
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Shopify-Access-Token") != "abcd" {
+				return httpmock.NewStringResponse(401, `{"errors": "unauthorized"}`), nil
+			}
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewStringResponse(200, `{
+				"data": {"shop": {"name": "fooshop"}},
+				"extensions": {"cost": {"requestedQueryCost": 1, "actualQueryCost": 1, "throttleStatus": {"maximumAvailable": 1000.0, "currentlyAvailable": 999, "restoreRate": 50.0}}}
+			}`), nil
+		},
+	)
+
+	resp := struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}{}
+	query := `query shop($id: ID!) { shop { name } }`
+	vars := map[string]interface{}{"id": "gid://shopify/Shop/1"}
+
+	extensions, err := client.GraphQL.QueryWithExtensions(context.Background(), query, vars, &resp)
+	if err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	if resp.Shop.Name != "fooshop" {
+		t.Errorf("GraphQL.Query returned %+v, expected shop name fooshop", resp)
+	}
+
+	if sent.Query != query || !reflect.DeepEqual(sent.Variables, vars) {
+		t.Errorf("GraphQL.Query sent %+v", sent)
+	}
+
+	actualCost := 1
+	expected := &GraphQLExtensions{
+		Cost: &GraphQLCost{
+			RequestedQueryCost: 1,
+			ActualQueryCost:    &actualCost,
+			ThrottleStatus: GraphQLThrottleStatus{
+				MaximumAvailable:   1000,
+				CurrentlyAvailable: 999,
+				RestoreRate:        50,
+			},
+		},
+	}
+	if !reflect.DeepEqual(extensions, expected) {
+		t.Errorf("GraphQL.QueryWithExtensions returned %+v, expected %+v", extensions, expected)
+	}
+}
+
+func TestGraphQLQueryErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		description string
+		responder   httpmock.Responder
+		expected    error
+	}{
+		{
+			"top level errors",
+			httpmock.NewStringResponder(200, `{"errors": [{"message": "Field 'foo' doesn't exist on type 'QueryRoot'", "locations": [{"line": 1, "column": 3}], "path": ["query", "foo"], "extensions": {"code": "undefinedField"}}]}`),
+			GraphQLErrors{{
+				Message:    "Field 'foo' doesn't exist on type 'QueryRoot'",
+				Locations:  []GraphQLErrorLocation{{Line: 1, Column: 3}},
+				Path:       []interface{}{"query", "foo"},
+				Extensions: GraphQLErrorExtensions{Code: "undefinedField"},
+			}},
+		},
+		{
+			"user errors",
+			httpmock.NewStringResponder(200, `{"data": {"productCreate": {"product": null, "userErrors": [{"field": ["input", "title"], "message": "Title can't be blank"}]}}}`),
+			UserErrors{{Field: []string{"input", "title"}, Message: "Title can't be blank"}},
+		},
+		{
+			"no user errors",
+			httpmock.NewStringResponder(200, `{"data": {"productCreate": {"product": {"id": "gid://shopify/Product/1"}, "userErrors": []}, "nodes": [null]}}`),
+			nil,
+		},
+		{
+			"http errors",
+			httpmock.NewStringResponder(401, `{"errors": "[API] Invalid API key or access token"}`),
+			ResponseError{Status: 401, Message: "[API] Invalid API key or access token"},
+		},
+	}
+
+	for _, c := range cases {
+		httpmock.RegisterResponder(
+			"POST",
+			fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+			c.responder,
+		)
+
+		err := client.GraphQL.Query(context.Background(), "mutation { foo }", nil, nil)
+		if !reflect.DeepEqual(err, c.expected) {
+			t.Errorf("%s: GraphQL.Query returned error %#v, expected %#v", c.description, err, c.expected)
+		}
+	}
+}
+
+func TestGraphQLErrorsError(t *testing.T) {
+	errs := GraphQLErrors{
+		{Message: "first", Extensions: GraphQLErrorExtensions{Code: "THROTTLED"}},
+		{Message: "second"},
+	}
+
+	if errs.Error() != "first, second" {
+		t.Errorf("GraphQLErrors.Error() returned %s", errs.Error())
+	}
+
+	if !errs.HasCode("THROTTLED") || errs.HasCode("ACCESS_DENIED") {
+		t.Errorf("GraphQLErrors.HasCode() returned an unexpected result")
+	}
+
+	userErrs := UserErrors{
+		{Field: []string{"input", "title"}, Message: "can't be blank"},
+		{Message: "Something went wrong"},
+	}
+
+	expected := "Something went wrong, input.title: can't be blank"
+	if userErrs.Error() != expected {
+		t.Errorf("UserErrors.Error() returned %s, expected %s", userErrs.Error(), expected)
+	}
+}
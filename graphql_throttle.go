@@ -0,0 +1,108 @@
+package go_shopify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// number of attempts for a throttled graphql query when WithRetry allows fewer
+	defaultGraphQLThrottleAttempts = 3
+
+	// wait before retrying a throttled query which did not report its cost
+	defaultGraphQLThrottleWait = time.Second
+
+	// number of query costs remembered, queries with inlined values would
+	// grow the costs of a long running client without bound otherwise
+	maxGraphQLQueryCosts = 1000
+)
+
+// graphQLBucket tracks Shopify's graphql cost bucket from the throttleStatus
+// of each response so queries can wait for enough budget before being sent.
+// See: https://shopify.dev/api/usage/rate-limits#graphql-admin-api-rate-limits
+type graphQLBucket struct {
+	mu sync.Mutex
+
+	status  GraphQLThrottleStatus
+	updated time.Time
+
+	// last requestedQueryCost of each query, the cost is only known after
+	// a query was sent once. Holds at most maxGraphQLQueryCosts queries.
+	costs map[string]int
+}
+
+// available returns the budget restored up to now. The caller must hold b.mu.
+func (b *graphQLBucket) available(now time.Time) float64 {
+	available := b.status.CurrentlyAvailable + now.Sub(b.updated).Seconds()*b.status.RestoreRate
+	if available > b.status.MaximumAvailable {
+		return b.status.MaximumAvailable
+	}
+	return available
+}
+
+// wait blocks until the bucket has enough budget for query, then reserves it.
+// Queries with an unknown cost, or a cost above the maximum which Shopify
+// rejects anyway, are sent right away.
+func (b *graphQLBucket) wait(ctx context.Context, query string) error {
+	for {
+		b.mu.Lock()
+		cost := float64(b.costs[query])
+		if cost == 0 || b.status.RestoreRate <= 0 || cost > b.status.MaximumAvailable {
+			b.mu.Unlock()
+			return nil
+		}
+
+		now := time.Now()
+		available := b.available(now)
+		if cost <= available {
+			b.status.CurrentlyAvailable = available - cost
+			b.updated = now
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((cost - available) / b.status.RestoreRate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// update records the cost and throttle status reported for query.
+func (b *graphQLBucket) update(query string, extensions *GraphQLExtensions) {
+	if extensions == nil || extensions.Cost == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.costs == nil {
+		b.costs = map[string]int{}
+	}
+	if _, ok := b.costs[query]; !ok && len(b.costs) >= maxGraphQLQueryCosts {
+		// forget an arbitrary query, its cost is learned again once it is sent
+		for q := range b.costs {
+			delete(b.costs, q)
+			break
+		}
+	}
+	b.costs[query] = extensions.Cost.RequestedQueryCost
+	b.status = extensions.Cost.ThrottleStatus
+	b.updated = time.Now()
+}
+
+// throttleStatus returns the last known status with the budget restored up
+// to now.
+func (b *graphQLBucket) throttleStatus() GraphQLThrottleStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := b.status
+	if !b.updated.IsZero() {
+		status.CurrentlyAvailable = b.available(time.Now())
+	}
+	return status
+}
@@ -0,0 +1,148 @@
+package go_shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func graphQLCostResponse(data string, requested int, available float64) string {
+	return fmt.Sprintf(`{%s"extensions": {"cost": {"requestedQueryCost": %d, "throttleStatus": {"maximumAvailable": 1000.0, "currentlyAvailable": %v, "restoreRate": 1000.0}}}}`,
+		data, requested, available)
+}
+
+func TestGraphQLWaitsForBudget(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, graphQLCostResponse(`"data": {},`, 100, 0)),
+	)
+
+	// the cost of the query is unknown so the first one goes out right away
+	start := time.Now()
+	if err := client.GraphQL.Query(context.Background(), "{ shop { name } }", nil, nil); err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("GraphQL.Query waited %s for a query with unknown cost", elapsed)
+	}
+
+	// the second one costs 100 with nothing available, restoring 1000 per second
+	start = time.Now()
+	if err := client.GraphQL.Query(context.Background(), "{ shop { name } }", nil, nil); err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("GraphQL.Query returned after %s, expected to wait for budget", elapsed)
+	}
+
+	status := client.GraphQL.ThrottleStatus()
+	if status.MaximumAvailable != 1000 || status.RestoreRate != 1000 {
+		t.Errorf("GraphQL.ThrottleStatus returned %+v", status)
+	}
+}
+
+func TestGraphQLRetriesThrottled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls int
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return httpmock.NewStringResponse(200, graphQLCostResponse(
+					`"errors": [{"message": "Throttled", "extensions": {"code": "THROTTLED"}}],`, 10, 0)), nil
+			}
+			return httpmock.NewStringResponse(200, graphQLCostResponse(`"data": {"shop": {"name": "fooshop"}},`, 10, 990)), nil
+		},
+	)
+
+	resp := struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}{}
+	if err := client.GraphQL.Query(context.Background(), "{ shop { name } }", nil, &resp); err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	if calls != 2 || resp.Shop.Name != "fooshop" {
+		t.Errorf("GraphQL.Query made %d calls and returned %+v, expected 2 calls and fooshop", calls, resp)
+	}
+}
+
+func TestGraphQLThrottledAttemptsExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls int
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(200, graphQLCostResponse(
+				`"errors": [{"message": "Throttled", "extensions": {"code": "THROTTLED"}}],`, 1, 0)), nil
+		},
+	)
+
+	err := client.GraphQL.Query(context.Background(), "{ shop { name } }", nil, nil)
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) || !gqlErrs.HasCode("THROTTLED") {
+		t.Errorf("GraphQL.Query returned error %v, expected THROTTLED", err)
+	}
+
+	if calls != maxRetries {
+		t.Errorf("GraphQL.Query made %d calls, expected %d", calls, maxRetries)
+	}
+}
+
+func TestGraphQLBucketWaitContextCancelled(t *testing.T) {
+	bucket := graphQLBucket{}
+	bucket.update("{ shop { name } }", &GraphQLExtensions{
+		Cost: &GraphQLCost{
+			RequestedQueryCost: 500,
+			ThrottleStatus: GraphQLThrottleStatus{
+				MaximumAvailable:   1000,
+				CurrentlyAvailable: 0,
+				RestoreRate:        50,
+			},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := bucket.wait(ctx, "{ shop { name } }")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("graphQLBucket.wait: expected error %v, actual %v", context.DeadlineExceeded, err)
+	}
+
+	// unknown queries are never held back
+	if err := bucket.wait(ctx, "{ products { id } }"); err != nil {
+		t.Errorf("graphQLBucket.wait returned error %v for an unknown query", err)
+	}
+}
+
+func TestGraphQLBucketCostsCapped(t *testing.T) {
+	bucket := graphQLBucket{}
+	extensions := &GraphQLExtensions{Cost: &GraphQLCost{RequestedQueryCost: 1}}
+	for i := 0; i < maxGraphQLQueryCosts+10; i++ {
+		bucket.update(fmt.Sprintf(`{ product(id: "gid://shopify/Product/%d") { title } }`, i), extensions)
+	}
+
+	if len(bucket.costs) != maxGraphQLQueryCosts {
+		t.Errorf("graphQLBucket remembered %d query costs, expected %d", len(bucket.costs), maxGraphQLQueryCosts)
+	}
+}
@@ -34,6 +34,11 @@ type ResponseError struct {
 	Status  int
 	Message string
 	Errors  []string
+
+	// FieldErrors holds the messages of validation errors by field, e.g.
+	// {"amount": ["must be greater than 0"]}. It is nil unless Shopify keyed
+	// the errors by field.
+	FieldErrors map[string][]string
 }
 
 // GetStatus returns http  response status
@@ -51,6 +56,11 @@ func (e ResponseError) GetErrors() []string {
 	return e.Errors
 }
 
+// GetFieldErrors returns the validation error messages by field
+func (e ResponseError) GetFieldErrors() map[string][]string {
+	return e.FieldErrors
+}
+
 func (e ResponseError) Error() string {
 	if e.Message != "" {
 		return e.Message
@@ -175,6 +185,7 @@ func CheckResponseError(r *http.Response) error {
 	case reflect.Map:
 		// A map, parse each error for each key in the map.
 		// json always serializes into map[string]interface{} for objects
+		responseError.FieldErrors = map[string][]string{}
 		for k, v := range shopifyError.Errors.(map[string]interface{}) {
 			switch reflect.TypeOf(v).Kind() {
 			// Check to make sure the interface is a slice
@@ -188,6 +199,7 @@ func CheckResponseError(r *http.Response) error {
 					}
 					topicAndElem := fmt.Sprintf("%v: %v", k, elem)
 					responseError.Errors = append(responseError.Errors, topicAndElem)
+					responseError.FieldErrors[k] = append(responseError.FieldErrors[k], fmt.Sprint(elem))
 				}
 			case reflect.String:
 				elem := v.(string)
@@ -196,6 +208,7 @@ func CheckResponseError(r *http.Response) error {
 				}
 				topicAndElem := fmt.Sprintf("%v: %v", k, elem)
 				responseError.Errors = append(responseError.Errors, topicAndElem)
+				responseError.FieldErrors[k] = append(responseError.FieldErrors[k], elem)
 			}
 		}
 	}
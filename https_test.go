@@ -127,3 +127,27 @@ func TestCheckResponseError(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckResponseErrorFieldErrors(t *testing.T) {
+	resp := httpmock.NewStringResponse(422, `{"errors": {"amount": ["must be greater than 0", "is invalid"], "kind": "is not included in the list"}}`)
+
+	err := CheckResponseError(resp)
+	responseError, ok := err.(ResponseError)
+	if !ok {
+		t.Fatalf("CheckResponseError(): expected ResponseError, actual %#v", err)
+	}
+
+	expected := map[string][]string{
+		"amount": {"must be greater than 0", "is invalid"},
+		"kind":   {"is not included in the list"},
+	}
+	if !reflect.DeepEqual(responseError.GetFieldErrors(), expected) {
+		t.Errorf("ResponseError.FieldErrors: expected %v, actual %v", expected, responseError.FieldErrors)
+	}
+
+	expectedMessage := "amount: is invalid, amount: must be greater than 0, kind: is not included in the list"
+	responseError.Message = ""
+	if responseError.Error() != expectedMessage {
+		t.Errorf("ResponseError.Error(): expected %s, actual %s", expectedMessage, responseError.Error())
+	}
+}
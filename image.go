@@ -0,0 +1,144 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+const imagesBasePath = "images"
+
+// ProductImageService is an interface for interacting with the image
+// endpoints of the Shopify API.
+// See https://help.shopify.com/api/reference/product_image
+type ProductImageService interface {
+	List(context.Context, int64, interface{}) ([]Image, error)
+	Count(context.Context, int64, interface{}) (int, error)
+	Get(context.Context, int64, int64, interface{}) (*Image, error)
+	Create(context.Context, int64, Image) (*Image, error)
+	Update(context.Context, int64, Image) (*Image, error)
+	Delete(context.Context, int64, int64) error
+}
+
+// ProductImageServiceOp handles communication with the image related methods
+// of the Shopify API.
+type ProductImageServiceOp struct {
+	client *Client
+}
+
+// Image represents a Shopify product image. A new image is created either
+// from a public URL in Src or from base64 encoded data in Attachment, see
+// NewImageFromReader.
+//
+// Fields left empty are not sent. To clear the alt text set Alt to a pointer
+// to an empty string, to remove all variants set VariantIDs to an empty,
+// non-nil slice.
+type Image struct {
+	ID                int64      `json:"id,omitempty"`
+	ProductID         int64      `json:"product_id,omitempty"`
+	Position          int        `json:"position,omitempty"`
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+	Width             int        `json:"width,omitempty"`
+	Height            int        `json:"height,omitempty"`
+	Src               string     `json:"src,omitempty"`
+	Attachment        string     `json:"attachment,omitempty"`
+	Filename          string     `json:"filename,omitempty"`
+	Alt               *string    `json:"alt,omitempty"`
+	VariantIDs        []int64    `json:"variant_ids,omitempty"`
+	AdminGraphqlAPIID string     `json:"admin_graphql_api_id,omitempty"`
+}
+
+// MarshalJSON sends an empty, non-nil VariantIDs as an empty list
+func (i Image) MarshalJSON() ([]byte, error) {
+	// image has the fields of Image but not this method
+	type image Image
+	data := struct {
+		image
+		VariantIDs *[]int64 `json:"variant_ids,omitempty"`
+	}{image: image(i)}
+	if i.VariantIDs != nil {
+		data.VariantIDs = &i.VariantIDs
+	}
+	return json.Marshal(data)
+}
+
+// NewImageFromReader reads the raw bytes of an image from r, e.g. an opened
+// file, and returns an Image with the base64 encoded data in Attachment,
+// ready to be passed to ProductImageService.Create.
+func NewImageFromReader(filename string, r io.Reader) (*Image, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Image{
+		Filename:   filename,
+		Attachment: base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+// ImageResource represents the result from the products/X/images/Y.json endpoint
+type ImageResource struct {
+	Image *Image `json:"image"`
+}
+
+// ImagesResource represents the result from the products/X/images.json endpoint
+type ImagesResource struct {
+	Images []Image `json:"images"`
+}
+
+// List images of a product
+func (s *ProductImageServiceOp) List(ctx context.Context, productID int64, options interface{}) ([]Image, error) {
+	path := fmt.Sprintf("%s/%d/%s.json", productsBasePath, productID, imagesBasePath)
+	resource := new(ImagesResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Images, err
+}
+
+// Count images of a product
+func (s *ProductImageServiceOp) Count(ctx context.Context, productID int64, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%d/%s/count.json", productsBasePath, productID, imagesBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual image of a product
+func (s *ProductImageServiceOp) Get(ctx context.Context, productID int64, imageID int64, options interface{}) (*Image, error) {
+	path := fmt.Sprintf("%s/%d/%s/%d.json", productsBasePath, productID, imagesBasePath, imageID)
+	resource := new(ImageResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Image, err
+}
+
+// Create a new image for a product. Set either Src or Attachment, and
+// VariantIDs to show the image for those variants.
+func (s *ProductImageServiceOp) Create(ctx context.Context, productID int64, image Image) (*Image, error) {
+	if image.Src == "" && image.Attachment == "" {
+		return nil, fmt.Errorf("image needs either a src or an attachment")
+	}
+
+	path := fmt.Sprintf("%s/%d/%s.json", productsBasePath, productID, imagesBasePath)
+	wrappedData := ImageResource{Image: &image}
+	resource := new(ImageResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Image, err
+}
+
+// Update an existing image of a product, e.g. its Alt text, Position or
+// VariantIDs. See Image for clearing the alt text or the variants.
+func (s *ProductImageServiceOp) Update(ctx context.Context, productID int64, image Image) (*Image, error) {
+	path := fmt.Sprintf("%s/%d/%s/%d.json", productsBasePath, productID, imagesBasePath, image.ID)
+	wrappedData := ImageResource{Image: &image}
+	resource := new(ImageResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Image, err
+}
+
+// Delete an existing image of a product
+func (s *ProductImageServiceOp) Delete(ctx context.Context, productID int64, imageID int64) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d/%s/%d.json", productsBasePath, productID, imagesBasePath, imageID))
+}
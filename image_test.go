@@ -0,0 +1,238 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func imageTests(t *testing.T, image Image) {
+	var expectedInt int64 = 850703190
+	if image.ID != expectedInt {
+		t.Errorf("Image.ID returned %+v, expected %+v", image.ID, expectedInt)
+	}
+
+	expectedInt = 632910392
+	if image.ProductID != expectedInt {
+		t.Errorf("Image.ProductID returned %+v, expected %+v", image.ProductID, expectedInt)
+	}
+
+	if image.Position != 1 {
+		t.Errorf("Image.Position returned %+v, expected %+v", image.Position, 1)
+	}
+
+	expectedAlt := "iPod in pink"
+	if image.Alt == nil || *image.Alt != expectedAlt {
+		t.Errorf("Image.Alt returned %+v, expected %+v", image.Alt, expectedAlt)
+	}
+
+	expectedVariantIDs := []int64{808950810}
+	if !reflect.DeepEqual(image.VariantIDs, expectedVariantIDs) {
+		t.Errorf("Image.VariantIDs returned %+v, expected %+v", image.VariantIDs, expectedVariantIDs)
+	}
+}
+
+func TestImageList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392/images.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("images.json")))
+
+	images, err := client.ProductImage.List(context.Background(), 632910392, nil)
+	if err != nil {
+		t.Errorf("ProductImage.List returned error: %v", err)
+	}
+
+	if len(images) != 2 || images[0].ID != 850703190 || images[1].Position != 2 {
+		t.Errorf("ProductImage.List returned %+v", images)
+	}
+}
+
+func TestImageCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/images/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 2}`))
+
+	cnt, err := client.ProductImage.Count(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("ProductImage.Count returned error: %v", err)
+	}
+
+	expected := 2
+	if cnt != expected {
+		t.Errorf("ProductImage.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestImageGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392/images/850703190.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("image.json")))
+
+	image, err := client.ProductImage.Get(context.Background(), 632910392, 850703190, nil)
+	if err != nil {
+		t.Fatalf("ProductImage.Get returned error: %v", err)
+	}
+
+	imageTests(t, *image)
+}
+
+func TestImageCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392/images.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("image.json")))
+
+	image := Image{
+		Src:        "https://cdn.shopify.com/s/files/1/0005/4838/0009/products/ipod-nano.png",
+		VariantIDs: []int64{808950810},
+	}
+	returnedImage, err := client.ProductImage.Create(context.Background(), 632910392, image)
+	if err != nil {
+		t.Fatalf("ProductImage.Create returned error: %v", err)
+	}
+
+	imageTests(t, *returnedImage)
+}
+
+func TestImageCreateFromReader(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent ImageResource
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392/images.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("image.json")), nil
+		})
+
+	image, err := NewImageFromReader("ipod-nano.png", strings.NewReader("image data"))
+	if err != nil {
+		t.Fatalf("NewImageFromReader returned error: %v", err)
+	}
+	alt := "iPod in pink"
+	image.Alt = &alt
+
+	_, err = client.ProductImage.Create(context.Background(), 632910392, *image)
+	if err != nil {
+		t.Fatalf("ProductImage.Create returned error: %v", err)
+	}
+
+	expected := &Image{
+		Filename:   "ipod-nano.png",
+		Attachment: "aW1hZ2UgZGF0YQ==",
+		Alt:        &alt,
+	}
+	if !reflect.DeepEqual(sent.Image, expected) {
+		t.Errorf("ProductImage.Create sent %+v, expected %+v", sent.Image, expected)
+	}
+}
+
+func TestImageCreateWithoutSource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.ProductImage.Create(context.Background(), 632910392, Image{Position: 1})
+	if err == nil {
+		t.Error("ProductImage.Create expected an error for an image without src or attachment")
+	}
+}
+
+func TestImageUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392/images/850703190.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("image.json")))
+
+	alt := "iPod in pink"
+	image := Image{
+		ID:       850703190,
+		Alt:      &alt,
+		Position: 1,
+	}
+	returnedImage, err := client.ProductImage.Update(context.Background(), 632910392, image)
+	if err != nil {
+		t.Fatalf("ProductImage.Update returned error: %v", err)
+	}
+
+	imageTests(t, *returnedImage)
+}
+
+func TestImageUpdateClear(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392/images/850703190.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			sent = nil
+			if err := json.Unmarshal(body, &sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("image.json")), nil
+		})
+
+	empty := ""
+	_, err := client.ProductImage.Update(context.Background(), 632910392, Image{
+		ID:         850703190,
+		Alt:        &empty,
+		VariantIDs: []int64{},
+	})
+	if err != nil {
+		t.Fatalf("ProductImage.Update returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":          float64(850703190),
+		"alt":         "",
+		"variant_ids": []interface{}{},
+	}
+	if !reflect.DeepEqual(sent["image"], expected) {
+		t.Errorf("ProductImage.Update sent %+v, expected %+v", sent["image"], expected)
+	}
+
+	// fields left empty are not sent
+	_, err = client.ProductImage.Update(context.Background(), 632910392, Image{ID: 850703190, Position: 2})
+	if err != nil {
+		t.Fatalf("ProductImage.Update returned error: %v", err)
+	}
+
+	expected = map[string]interface{}{
+		"id":       float64(850703190),
+		"position": float64(2),
+	}
+	if !reflect.DeepEqual(sent["image"], expected) {
+		t.Errorf("ProductImage.Update sent %+v, expected %+v", sent["image"], expected)
+	}
+}
+
+func TestImageDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/images/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.ProductImage.Delete(context.Background(), 1, 2)
+	if err != nil {
+		t.Errorf("ProductImage.Delete returned error: %v", err)
+	}
+}
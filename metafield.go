@@ -0,0 +1,297 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Owner resources of metafields, see Client.NewMetafieldService
+const (
+	MetafieldOwnerProducts    = "products"
+	MetafieldOwnerVariants    = "variants"
+	MetafieldOwnerCustomers   = "customers"
+	MetafieldOwnerOrders      = "orders"
+	MetafieldOwnerCollections = "collections"
+	MetafieldOwnerPages       = "pages"
+	MetafieldOwnerBlogs       = "blogs"
+)
+
+// Metafield types, list types are the base type prefixed with
+// MetafieldTypeListPrefix, e.g. "list.single_line_text_field".
+// See: https://shopify.dev/apps/metafields/types
+const (
+	MetafieldTypeSingleLineText   = "single_line_text_field"
+	MetafieldTypeMultiLineText    = "multi_line_text_field"
+	MetafieldTypeJSON             = "json"
+	MetafieldTypeNumberInteger    = "number_integer"
+	MetafieldTypeNumberDecimal    = "number_decimal"
+	MetafieldTypeBoolean          = "boolean"
+	MetafieldTypeDate             = "date"
+	MetafieldTypeDateTime         = "date_time"
+	MetafieldTypeMoney            = "money"
+	MetafieldTypeURL              = "url"
+	MetafieldTypeColor            = "color"
+	MetafieldTypeProductReference = "product_reference"
+	MetafieldTypeVariantReference = "variant_reference"
+
+	MetafieldTypeListPrefix = "list."
+)
+
+const metafieldDateLayout = "2006-01-02"
+
+// MetafieldService is an interface for interfacing with the metafield endpoints
+// of the Shopify API.
+// See: https://help.shopify.com/api/reference/metafield
+type MetafieldService interface {
+	List(context.Context, interface{}) ([]Metafield, error)
+	Count(context.Context, interface{}) (int, error)
+	Get(context.Context, int64, interface{}) (*Metafield, error)
+	Create(context.Context, Metafield) (*Metafield, error)
+	Update(context.Context, Metafield) (*Metafield, error)
+	Delete(context.Context, int64) error
+}
+
+// MetafieldsService is an interface for other Shopify resources
+// to interface with the metafield endpoints of the Shopify API.
+// See: https://help.shopify.com/api/reference/metafield
+type MetafieldsService interface {
+	ListMetafields(context.Context, int64, interface{}) ([]Metafield, error)
+	CountMetafields(context.Context, int64, interface{}) (int, error)
+	GetMetafield(context.Context, int64, int64, interface{}) (*Metafield, error)
+	CreateMetafield(context.Context, int64, Metafield) (*Metafield, error)
+	UpdateMetafield(context.Context, int64, Metafield) (*Metafield, error)
+	DeleteMetafield(context.Context, int64, int64) error
+}
+
+// MetafieldServiceOp handles communication with the metafield
+// related methods of the Shopify API.
+type MetafieldServiceOp struct {
+	client     *Client
+	resource   string
+	resourceID int64
+}
+
+// Metafield represents a Shopify metafield.
+type Metafield struct {
+	ID                int64       `json:"id,omitempty"`
+	Key               string      `json:"key,omitempty"`
+	Value             interface{} `json:"value,omitempty"`
+	Type              string      `json:"type,omitempty"`
+	Namespace         string      `json:"namespace,omitempty"`
+	Description       string      `json:"description,omitempty"`
+	OwnerID           int64       `json:"owner_id,omitempty"`
+	OwnerResource     string      `json:"owner_resource,omitempty"`
+	CreatedAt         *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time  `json:"updated_at,omitempty"`
+	AdminGraphqlAPIID string      `json:"admin_graphql_api_id,omitempty"`
+}
+
+// MetafieldListOptions are the options for listing and counting metafields
+type MetafieldListOptions struct {
+	ListOptions
+	Namespace string `url:"namespace,omitempty"`
+	Key       string `url:"key,omitempty"`
+	Type      string `url:"type,omitempty"`
+}
+
+// MetafieldMoney is the value of a metafield of type money
+type MetafieldMoney struct {
+	Amount       decimal.Decimal `json:"amount"`
+	CurrencyCode string          `json:"currency_code"`
+}
+
+// MetafieldResource represents the result from the metafields/X.json endpoint
+type MetafieldResource struct {
+	Metafield *Metafield `json:"metafield"`
+}
+
+// MetafieldsResource represents the result from the metafields.json endpoint
+type MetafieldsResource struct {
+	Metafields []Metafield `json:"metafields"`
+}
+
+// List metafields
+func (s *MetafieldServiceOp) List(ctx context.Context, options interface{}) ([]Metafield, error) {
+	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s.json", prefix)
+	resource := new(MetafieldsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Metafields, err
+}
+
+// Count metafields
+func (s *MetafieldServiceOp) Count(ctx context.Context, options interface{}) (int, error) {
+	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/count.json", prefix)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual metafield
+func (s *MetafieldServiceOp) Get(ctx context.Context, metafieldID int64, options interface{}) (*Metafield, error) {
+	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%d.json", prefix, metafieldID)
+	resource := new(MetafieldResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Metafield, err
+}
+
+// Create a new metafield
+func (s *MetafieldServiceOp) Create(ctx context.Context, metafield Metafield) (*Metafield, error) {
+	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s.json", prefix)
+	wrappedData := MetafieldResource{Metafield: &metafield}
+	resource := new(MetafieldResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Metafield, err
+}
+
+// Update an existing metafield
+func (s *MetafieldServiceOp) Update(ctx context.Context, metafield Metafield) (*Metafield, error) {
+	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%d.json", prefix, metafield.ID)
+	wrappedData := MetafieldResource{Metafield: &metafield}
+	resource := new(MetafieldResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Metafield, err
+}
+
+// Delete an existing metafield
+func (s *MetafieldServiceOp) Delete(ctx context.Context, metafieldID int64) error {
+	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", prefix, metafieldID))
+}
+
+// NewMetafieldService returns a MetafieldService for the metafields of the
+// given owner, e.g. NewMetafieldService(MetafieldOwnerCollections, id). The
+// shop metafields are available through Client.Metafield.
+func (c *Client) NewMetafieldService(resource string, resourceID int64) MetafieldService {
+	return &MetafieldServiceOp{client: c, resource: resource, resourceID: resourceID}
+}
+
+// EncodeValue sets the Value of the metafield to v encoded according to its
+// Type, which has to be set first:
+//   - json, money and list types: any value encoding to the matching JSON
+//   - number_integer: any integer
+//   - number_decimal: decimal.Decimal or any float
+//   - boolean: bool
+//   - date and date_time: time.Time
+//   - any other type: string
+func (m *Metafield) EncodeValue(v interface{}) error {
+	value, err := encodeMetafieldValue(m.Type, v)
+	if err != nil {
+		return err
+	}
+	m.Value = value
+	return nil
+}
+
+// DecodeValue decodes the Value of the metafield according to its Type into
+// v, which has to be a pointer to a type matching the ones of EncodeValue,
+// e.g. *int64 for number_integer or *[]string for list.single_line_text_field.
+func (m *Metafield) DecodeValue(v interface{}) error {
+	if m.Value == nil {
+		return fmt.Errorf("metafield %s.%s has no value", m.Namespace, m.Key)
+	}
+
+	raw, ok := m.Value.(string)
+	if !ok {
+		// older api versions return numbers, booleans and json as is
+		b, err := json.Marshal(m.Value)
+		if err != nil {
+			return err
+		}
+		raw = string(b)
+	}
+
+	switch m.Type {
+	case MetafieldTypeDate, MetafieldTypeDateTime:
+		t, ok := v.(*time.Time)
+		if !ok {
+			return fmt.Errorf("metafield type %s decodes into *time.Time, not %T", m.Type, v)
+		}
+		layout := time.RFC3339
+		if m.Type == MetafieldTypeDate {
+			layout = metafieldDateLayout
+		}
+		parsed, err := time.Parse(layout, raw)
+		if err != nil {
+			return err
+		}
+		*t = parsed
+		return nil
+	case MetafieldTypeJSON, MetafieldTypeMoney, MetafieldTypeNumberInteger, MetafieldTypeNumberDecimal, MetafieldTypeBoolean:
+		return json.Unmarshal([]byte(raw), v)
+	}
+
+	if strings.HasPrefix(m.Type, MetafieldTypeListPrefix) {
+		return json.Unmarshal([]byte(raw), v)
+	}
+
+	// textual types, quoted to decode into any string type
+	quoted, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(quoted, v)
+}
+
+// encodeMetafieldValue encodes v as the string value of a metafield of the
+// given type.
+func encodeMetafieldValue(metafieldType string, v interface{}) (string, error) {
+	switch metafieldType {
+	case "":
+		return "", fmt.Errorf("metafield type is required to encode its value")
+	case MetafieldTypeNumberInteger:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(rv.Int(), 10), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(rv.Uint(), 10), nil
+		}
+	case MetafieldTypeNumberDecimal:
+		switch n := v.(type) {
+		case decimal.Decimal:
+			return n.String(), nil
+		case *decimal.Decimal:
+			if n != nil {
+				return n.String(), nil
+			}
+		case float32:
+			return decimal.NewFromFloat32(n).String(), nil
+		case float64:
+			return decimal.NewFromFloat(n).String(), nil
+		}
+	case MetafieldTypeBoolean:
+		if b, ok := v.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+	case MetafieldTypeDate, MetafieldTypeDateTime:
+		if t, ok := v.(time.Time); ok {
+			if metafieldType == MetafieldTypeDate {
+				return t.Format(metafieldDateLayout), nil
+			}
+			return t.Format(time.RFC3339), nil
+		}
+	case MetafieldTypeJSON, MetafieldTypeMoney:
+		b, err := json.Marshal(v)
+		return string(b), err
+	default:
+		if strings.HasPrefix(metafieldType, MetafieldTypeListPrefix) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		}
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("metafield type %s cannot encode a value of type %T", metafieldType, v)
+}
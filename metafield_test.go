@@ -0,0 +1,431 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func metafieldTests(t *testing.T, metafield Metafield) {
+	// Check that ID is assigned to the returned metafield
+	var expectedInt int64 = 721389482
+	if metafield.ID != expectedInt {
+		t.Errorf("Metafield.ID returned %+v, expected %+v", metafield.ID, expectedInt)
+	}
+
+	cases := []struct {
+		field    string
+		actual   string
+		expected string
+	}{
+		{"Namespace", metafield.Namespace, "affiliates"},
+		{"Key", metafield.Key, "app_key"},
+		{"Type", metafield.Type, MetafieldTypeSingleLineText},
+		{"OwnerResource", metafield.OwnerResource, "variant"},
+		{"AdminGraphqlAPIID", metafield.AdminGraphqlAPIID, "gid://shopify/Metafield/721389482"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("Metafield.%s returned %+v, expected %+v", c.field, c.actual, c.expected)
+		}
+	}
+
+	expectedTime := time.Date(2021, time.October, 1, 20, 51, 24, 0, time.UTC)
+	if metafield.CreatedAt == nil || !metafield.CreatedAt.Equal(expectedTime) {
+		t.Errorf("Metafield.CreatedAt returned %+v, expected %+v", metafield.CreatedAt, expectedTime)
+	}
+}
+
+func TestMetafieldList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":1},{"id":2}]}`))
+
+	metafields, err := client.Metafield.List(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Metafield.List returned error: %v", err)
+	}
+
+	expected := []Metafield{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Metafield.List returned %+v, expected %+v", metafields, expected)
+	}
+}
+
+func TestMetafieldListFilter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"namespace": "affiliates", "key": "app_key", "limit": "10"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":1}]}`))
+
+	options := MetafieldListOptions{
+		ListOptions: ListOptions{Limit: 10},
+		Namespace:   "affiliates",
+		Key:         "app_key",
+	}
+
+	metafields, err := client.Metafield.List(context.Background(), options)
+	if err != nil {
+		t.Errorf("Metafield.List returned error: %v", err)
+	}
+
+	expected := []Metafield{{ID: 1}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Metafield.List returned %+v, expected %+v", metafields, expected)
+	}
+}
+
+func TestMetafieldCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 3}`))
+
+	cnt, err := client.Metafield.Count(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Metafield.Count returned error: %v", err)
+	}
+
+	expected := 3
+	if cnt != expected {
+		t.Errorf("Metafield.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestMetafieldGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields/721389482.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("metafield.json")))
+
+	metafield, err := client.Metafield.Get(context.Background(), 721389482, nil)
+	if err != nil {
+		t.Fatalf("Metafield.Get returned error: %v", err)
+	}
+
+	metafieldTests(t, *metafield)
+}
+
+func TestMetafieldCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields.json", client.pathPrefix),
+		httpmock.NewBytesResponder(201, loadFixture("metafield.json")))
+
+	metafield := Metafield{
+		Namespace: "affiliates",
+		Key:       "app_key",
+		Value:     "app_key",
+		Type:      MetafieldTypeSingleLineText,
+	}
+
+	returnedMetafield, err := client.Metafield.Create(context.Background(), metafield)
+	if err != nil {
+		t.Fatalf("Metafield.Create returned error: %v", err)
+	}
+
+	metafieldTests(t, *returnedMetafield)
+}
+
+func TestMetafieldUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields/721389482.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("metafield.json")))
+
+	metafield := Metafield{
+		ID:    721389482,
+		Value: "app_key",
+	}
+
+	returnedMetafield, err := client.Metafield.Update(context.Background(), metafield)
+	if err != nil {
+		t.Fatalf("Metafield.Update returned error: %v", err)
+	}
+
+	metafieldTests(t, *returnedMetafield)
+}
+
+func TestMetafieldDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Metafield.Delete(context.Background(), 1)
+	if err != nil {
+		t.Errorf("Metafield.Delete returned error: %v", err)
+	}
+}
+
+func TestMetafieldOwners(t *testing.T) {
+	setup()
+	defer teardown()
+
+	owners := []string{
+		MetafieldOwnerProducts,
+		MetafieldOwnerVariants,
+		MetafieldOwnerCustomers,
+		MetafieldOwnerOrders,
+		MetafieldOwnerCollections,
+		MetafieldOwnerPages,
+		MetafieldOwnerBlogs,
+	}
+
+	for _, owner := range owners {
+		httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/%s/1/metafields.json", client.pathPrefix, owner),
+			httpmock.NewStringResponder(200, `{"metafields": [{"id":2}]}`))
+		httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/%s/1/metafields/2.json", client.pathPrefix, owner),
+			httpmock.NewStringResponder(200, "{}"))
+
+		metafieldService := client.NewMetafieldService(owner, 1)
+
+		metafields, err := metafieldService.List(context.Background(), nil)
+		if err != nil {
+			t.Errorf("%s metafields List returned error: %v", owner, err)
+		}
+
+		expected := []Metafield{{ID: 2}}
+		if !reflect.DeepEqual(metafields, expected) {
+			t.Errorf("%s metafields List returned %+v, expected %+v", owner, metafields, expected)
+		}
+
+		err = metafieldService.Delete(context.Background(), 2)
+		if err != nil {
+			t.Errorf("%s metafields Delete returned error: %v", owner, err)
+		}
+	}
+}
+
+func TestProductMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/metafields.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":1},{"id":2}]}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/metafields/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 2}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/metafields.json", client.pathPrefix),
+		httpmock.NewBytesResponder(201, loadFixture("metafield.json")))
+
+	metafields, err := client.Product.ListMetafields(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("Product.ListMetafields() returned error: %v", err)
+	}
+
+	expected := []Metafield{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Product.ListMetafields() returned %+v, expected %+v", metafields, expected)
+	}
+
+	cnt, err := client.Product.CountMetafields(context.Background(), 1, nil)
+	if err != nil || cnt != 2 {
+		t.Errorf("Product.CountMetafields() returned %d, %v, expected 2", cnt, err)
+	}
+
+	metafield, err := client.Product.CreateMetafield(context.Background(), 1, Metafield{Namespace: "affiliates", Key: "app_key"})
+	if err != nil {
+		t.Fatalf("Product.CreateMetafield() returned error: %v", err)
+	}
+
+	metafieldTests(t, *metafield)
+}
+
+func TestOrderMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/metafields/721389482.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("metafield.json")))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/metafields/721389482.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("metafield.json")))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/metafields/721389482.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	metafield, err := client.Order.GetMetafield(context.Background(), 1, 721389482, nil)
+	if err != nil {
+		t.Fatalf("Order.GetMetafield() returned error: %v", err)
+	}
+	metafieldTests(t, *metafield)
+
+	metafield, err = client.Order.UpdateMetafield(context.Background(), 1, Metafield{ID: 721389482, Value: "app_key"})
+	if err != nil {
+		t.Fatalf("Order.UpdateMetafield() returned error: %v", err)
+	}
+	metafieldTests(t, *metafield)
+
+	err = client.Order.DeleteMetafield(context.Background(), 1, 721389482)
+	if err != nil {
+		t.Errorf("Order.DeleteMetafield() returned error: %v", err)
+	}
+}
+
+func TestMetafieldEncodeValue(t *testing.T) {
+	price := decimal.RequireFromString("5.99")
+
+	cases := []struct {
+		metafieldType string
+		value         interface{}
+		expected      string
+	}{
+		{MetafieldTypeSingleLineText, "hello", "hello"},
+		{MetafieldTypeNumberInteger, 42, "42"},
+		{MetafieldTypeNumberInteger, int64(-7), "-7"},
+		{MetafieldTypeNumberInteger, uint8(7), "7"},
+		{MetafieldTypeNumberDecimal, price, "5.99"},
+		{MetafieldTypeNumberDecimal, &price, "5.99"},
+		{MetafieldTypeNumberDecimal, 1.5, "1.5"},
+		{MetafieldTypeBoolean, true, "true"},
+		{MetafieldTypeDate, time.Date(2022, time.February, 2, 15, 4, 5, 0, time.UTC), "2022-02-02"},
+		{MetafieldTypeDateTime, time.Date(2022, time.February, 2, 15, 4, 5, 0, time.UTC), "2022-02-02T15:04:05Z"},
+		{MetafieldTypeJSON, map[string]int{"ingredients": 3}, `{"ingredients":3}`},
+		{MetafieldTypeMoney, MetafieldMoney{Amount: price, CurrencyCode: "CAD"}, `{"amount":"5.99","currency_code":"CAD"}`},
+		{"list.single_line_text_field", []string{"a", "b"}, `["a","b"]`},
+		{"list.number_integer", []int{1, 2}, `[1,2]`},
+	}
+
+	for _, c := range cases {
+		metafield := Metafield{Type: c.metafieldType}
+		if err := metafield.EncodeValue(c.value); err != nil {
+			t.Errorf("Metafield.EncodeValue(%v) as %s returned error: %v", c.value, c.metafieldType, err)
+			continue
+		}
+
+		if metafield.Value != c.expected {
+			t.Errorf("Metafield.EncodeValue(%v) as %s returned %v, expected %v", c.value, c.metafieldType, metafield.Value, c.expected)
+		}
+	}
+}
+
+func TestMetafieldEncodeValueError(t *testing.T) {
+	cases := []struct {
+		metafieldType string
+		value         interface{}
+	}{
+		{"", "no type"},
+		{MetafieldTypeNumberInteger, "42"},
+		{MetafieldTypeNumberDecimal, "1.5"},
+		{MetafieldTypeBoolean, "true"},
+		{MetafieldTypeDateTime, "2022-02-02"},
+		{MetafieldTypeSingleLineText, 42},
+		{MetafieldTypeJSON, func() {}},
+	}
+
+	for _, c := range cases {
+		metafield := Metafield{Type: c.metafieldType, Value: "unchanged"}
+		if err := metafield.EncodeValue(c.value); err == nil {
+			t.Errorf("Metafield.EncodeValue(%v) as %s expected an error", c.value, c.metafieldType)
+		}
+
+		if metafield.Value != "unchanged" {
+			t.Errorf("Metafield.EncodeValue(%v) as %s changed the value to %v", c.value, c.metafieldType, metafield.Value)
+		}
+	}
+}
+
+func TestMetafieldDecodeValue(t *testing.T) {
+	var (
+		text     string
+		integer  int64
+		number   decimal.Decimal
+		boolean  bool
+		date     time.Time
+		dateTime time.Time
+		object   map[string]interface{}
+		money    MetafieldMoney
+		list     []string
+		numbers  []int
+	)
+
+	cases := []struct {
+		metafieldType string
+		value         interface{}
+		target        interface{}
+		expected      interface{}
+	}{
+		{MetafieldTypeMultiLineText, "hello\nworld", &text, "hello\nworld"},
+		{MetafieldTypeNumberInteger, "42", &integer, int64(42)},
+		// older api versions return numbers as is
+		{MetafieldTypeNumberInteger, float64(43), &integer, int64(43)},
+		{MetafieldTypeNumberDecimal, "5.99", &number, decimal.RequireFromString("5.99")},
+		{MetafieldTypeBoolean, "true", &boolean, true},
+		{MetafieldTypeDate, "2022-02-02", &date, time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)},
+		{MetafieldTypeDateTime, "2022-02-02T15:04:05Z", &dateTime, time.Date(2022, time.February, 2, 15, 4, 5, 0, time.UTC)},
+		{MetafieldTypeJSON, `{"ingredients":["flour"]}`, &object, map[string]interface{}{"ingredients": []interface{}{"flour"}}},
+		{MetafieldTypeJSON, map[string]interface{}{"ingredients": 3}, &object, map[string]interface{}{"ingredients": float64(3)}},
+		{MetafieldTypeMoney, `{"amount":"5.99","currency_code":"CAD"}`, &money, MetafieldMoney{Amount: decimal.RequireFromString("5.99"), CurrencyCode: "CAD"}},
+		{"list.single_line_text_field", `["a","b"]`, &list, []string{"a", "b"}},
+		{"list.number_integer", `[1,2]`, &numbers, []int{1, 2}},
+	}
+
+	for _, c := range cases {
+		metafield := Metafield{Type: c.metafieldType, Value: c.value}
+		if err := metafield.DecodeValue(c.target); err != nil {
+			t.Errorf("Metafield.DecodeValue(%v) as %s returned error: %v", c.value, c.metafieldType, err)
+			continue
+		}
+
+		actual := reflect.ValueOf(c.target).Elem().Interface()
+		if d, ok := actual.(decimal.Decimal); ok {
+			if !d.Equal(c.expected.(decimal.Decimal)) {
+				t.Errorf("Metafield.DecodeValue(%v) as %s returned %v, expected %v", c.value, c.metafieldType, actual, c.expected)
+			}
+			continue
+		}
+		if tm, ok := actual.(time.Time); ok {
+			if !tm.Equal(c.expected.(time.Time)) {
+				t.Errorf("Metafield.DecodeValue(%v) as %s returned %v, expected %v", c.value, c.metafieldType, actual, c.expected)
+			}
+			continue
+		}
+		if m, ok := actual.(MetafieldMoney); ok {
+			expected := c.expected.(MetafieldMoney)
+			if !m.Amount.Equal(expected.Amount) || m.CurrencyCode != expected.CurrencyCode {
+				t.Errorf("Metafield.DecodeValue(%v) as %s returned %v, expected %v", c.value, c.metafieldType, actual, c.expected)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Metafield.DecodeValue(%v) as %s returned %v, expected %v", c.value, c.metafieldType, actual, c.expected)
+		}
+	}
+}
+
+func TestMetafieldDecodeValueError(t *testing.T) {
+	var (
+		text    string
+		integer int
+	)
+
+	cases := []struct {
+		metafield Metafield
+		target    interface{}
+	}{
+		{Metafield{Type: MetafieldTypeSingleLineText}, &text},
+		{Metafield{Type: MetafieldTypeNumberInteger, Value: "forty-two"}, &integer},
+		{Metafield{Type: MetafieldTypeDateTime, Value: "2022-02-02T15:04:05Z"}, &text},
+		{Metafield{Type: MetafieldTypeDate, Value: "02/02/2022"}, new(time.Time)},
+	}
+
+	for _, c := range cases {
+		if err := c.metafield.DecodeValue(c.target); err == nil {
+			t.Errorf("Metafield.DecodeValue(%v) as %s expected an error", c.metafield.Value, c.metafield.Type)
+		}
+	}
+}
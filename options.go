@@ -50,12 +50,33 @@ func WithVersion(apiVersion string) Option {
 	}
 }
 
+// WithRetry sets the maximum number of attempts for a request, see
+// WithRetryPolicy for which failures are retried.
 func WithRetry(retries int) Option {
 	return func(c *Client) {
 		c.retries = retries
 	}
 }
 
+// WithRetryPolicy sets the policy deciding which failed requests are retried
+// and how long to wait in between. Defaults to DefaultRetryPolicy. It has no
+// effect unless WithRetry is used as well.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRateLimiter makes the client wait for room in the given leaky bucket
+// before sending each REST request, GraphQL queries have their own cost
+// based limit. Pass the same RateLimiter to every client of a shop so they
+// share the bucket.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
 func WithLogger(logger LeveledLoggerInterface) Option {
 	return func(c *Client) {
 		c.log = logger
@@ -72,3 +72,20 @@ func TestWithHTTPClient(t *testing.T) {
 		t.Errorf("WithVersion client.Client = %s, expected %s", c.Client.Timeout, expected)
 	}
 }
+
+func TestWithRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(DefaultRateLimitBucketSize, DefaultRateLimitLeakRate)
+	c := NewClient(app, "fooshop", "abcd", WithRateLimiter(limiter))
+
+	if c.rateLimiter != limiter {
+		t.Errorf("WithRateLimiter client.rateLimiter = %v, expected %v", c.rateLimiter, limiter)
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd", WithRetryPolicy(ServerErrorRetryPolicy))
+
+	if c.retryPolicy != ServerErrorRetryPolicy {
+		t.Errorf("WithRetryPolicy client.retryPolicy = %v, expected %v", c.retryPolicy, ServerErrorRetryPolicy)
+	}
+}
@@ -0,0 +1,457 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const ordersBasePath = "orders"
+
+// OrderService is an interface for interfacing with the order endpoints of
+// the Shopify API.
+// See: https://help.shopify.com/api/reference/order
+type OrderService interface {
+	List(context.Context, interface{}) ([]Order, error)
+	ListWithPagination(context.Context, interface{}) ([]Order, *Pagination, error)
+	Count(context.Context, interface{}) (int, error)
+	Get(context.Context, int64, interface{}) (*Order, error)
+	Create(context.Context, Order) (*Order, error)
+	Update(context.Context, Order) (*Order, error)
+	Delete(context.Context, int64) error
+	Close(context.Context, int64) (*Order, error)
+	Open(context.Context, int64) (*Order, error)
+	Cancel(context.Context, int64, interface{}) (*Order, error)
+
+	// MetafieldsService used for Order resource to communicate with Metafields resource
+	MetafieldsService
+
+	// FulfillmentsService used for Order resource to communicate with Fulfillments resource
+	FulfillmentsService
+}
+
+// OrderServiceOp handles communication with the order related methods of the
+// Shopify API.
+type OrderServiceOp struct {
+	client *Client
+}
+
+// OrderStatus is the status filter of OrderListOptions
+type OrderStatus string
+
+const (
+	OrderStatusOpen      OrderStatus = "open"
+	OrderStatusClosed    OrderStatus = "closed"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusAny       OrderStatus = "any"
+)
+
+// OrderFinancialStatus represents the financial status of an order. The
+// unpaid and any values are only valid as a filter.
+type OrderFinancialStatus string
+
+const (
+	OrderFinancialStatusAuthorized        OrderFinancialStatus = "authorized"
+	OrderFinancialStatusPending           OrderFinancialStatus = "pending"
+	OrderFinancialStatusPaid              OrderFinancialStatus = "paid"
+	OrderFinancialStatusPartiallyPaid     OrderFinancialStatus = "partially_paid"
+	OrderFinancialStatusRefunded          OrderFinancialStatus = "refunded"
+	OrderFinancialStatusVoided            OrderFinancialStatus = "voided"
+	OrderFinancialStatusPartiallyRefunded OrderFinancialStatus = "partially_refunded"
+	OrderFinancialStatusUnpaid            OrderFinancialStatus = "unpaid"
+	OrderFinancialStatusAny               OrderFinancialStatus = "any"
+)
+
+// OrderFulfillmentStatus represents the fulfillment status of an order or a
+// line item. An unfulfilled order has no fulfillment status at all, the
+// shipped, unshipped, unfulfilled and any values are only valid as a filter.
+type OrderFulfillmentStatus string
+
+const (
+	OrderFulfillmentStatusFulfilled   OrderFulfillmentStatus = "fulfilled"
+	OrderFulfillmentStatusPartial     OrderFulfillmentStatus = "partial"
+	OrderFulfillmentStatusRestocked   OrderFulfillmentStatus = "restocked"
+	OrderFulfillmentStatusShipped     OrderFulfillmentStatus = "shipped"
+	OrderFulfillmentStatusUnshipped   OrderFulfillmentStatus = "unshipped"
+	OrderFulfillmentStatusUnfulfilled OrderFulfillmentStatus = "unfulfilled"
+	OrderFulfillmentStatusAny         OrderFulfillmentStatus = "any"
+)
+
+// OrderCancelReason is the reason an order was cancelled
+type OrderCancelReason string
+
+const (
+	OrderCancelReasonCustomer  OrderCancelReason = "customer"
+	OrderCancelReasonFraud     OrderCancelReason = "fraud"
+	OrderCancelReasonInventory OrderCancelReason = "inventory"
+	OrderCancelReasonDeclined  OrderCancelReason = "declined"
+	OrderCancelReasonOther     OrderCancelReason = "other"
+)
+
+// OrderListOptions are the options for listing and counting orders
+type OrderListOptions struct {
+	ListOptions
+	Status            OrderStatus            `url:"status,omitempty"`
+	FinancialStatus   OrderFinancialStatus   `url:"financial_status,omitempty"`
+	FulfillmentStatus OrderFulfillmentStatus `url:"fulfillment_status,omitempty"`
+	ProcessedAtMin    time.Time              `url:"processed_at_min,omitempty"`
+	ProcessedAtMax    time.Time              `url:"processed_at_max,omitempty"`
+	// AttributionAppID is the id of the app which created the orders, or
+	// "current" for the app making the request.
+	AttributionAppID string `url:"attribution_app_id,omitempty"`
+}
+
+// OrderCancelOptions are the options for cancelling an order
+type OrderCancelOptions struct {
+	Reason OrderCancelReason `json:"reason,omitempty"`
+	// Email sends a notification to the customer
+	Email bool `json:"email,omitempty"`
+	// Amount is refunded to the customer along with the cancellation
+	Amount   *decimal.Decimal `json:"amount,omitempty"`
+	Currency string           `json:"currency,omitempty"`
+}
+
+// Order represents a Shopify order
+type Order struct {
+	ID                     int64                  `json:"id,omitempty"`
+	Name                   string                 `json:"name,omitempty"`
+	Email                  string                 `json:"email,omitempty"`
+	Phone                  string                 `json:"phone,omitempty"`
+	Number                 int                    `json:"number,omitempty"`
+	OrderNumber            int                    `json:"order_number,omitempty"`
+	Token                  string                 `json:"token,omitempty"`
+	Note                   string                 `json:"note,omitempty"`
+	NoteAttributes         []NoteAttribute        `json:"note_attributes,omitempty"`
+	Tags                   string                 `json:"tags,omitempty"`
+	Test                   *bool                  `json:"test,omitempty"`
+	Confirmed              *bool                  `json:"confirmed,omitempty"`
+	BuyerAcceptsMarketing  *bool                  `json:"buyer_accepts_marketing,omitempty"`
+	CreatedAt              *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt              *time.Time             `json:"updated_at,omitempty"`
+	ProcessedAt            *time.Time             `json:"processed_at,omitempty"`
+	ClosedAt               *time.Time             `json:"closed_at,omitempty"`
+	CancelledAt            *time.Time             `json:"cancelled_at,omitempty"`
+	CancelReason           OrderCancelReason      `json:"cancel_reason,omitempty"`
+	FinancialStatus        OrderFinancialStatus   `json:"financial_status,omitempty"`
+	FulfillmentStatus      OrderFulfillmentStatus `json:"fulfillment_status,omitempty"`
+	Currency               string                 `json:"currency,omitempty"`
+	PresentmentCurrency    string                 `json:"presentment_currency,omitempty"`
+	TotalPrice             *decimal.Decimal       `json:"total_price,omitempty"`
+	SubtotalPrice          *decimal.Decimal       `json:"subtotal_price,omitempty"`
+	TotalLineItemsPrice    *decimal.Decimal       `json:"total_line_items_price,omitempty"`
+	TotalDiscounts         *decimal.Decimal       `json:"total_discounts,omitempty"`
+	TotalTax               *decimal.Decimal       `json:"total_tax,omitempty"`
+	TotalTipReceived       *decimal.Decimal       `json:"total_tip_received,omitempty"`
+	CurrentTotalPrice      *decimal.Decimal       `json:"current_total_price,omitempty"`
+	CurrentSubtotalPrice   *decimal.Decimal       `json:"current_subtotal_price,omitempty"`
+	CurrentTotalDiscounts  *decimal.Decimal       `json:"current_total_discounts,omitempty"`
+	CurrentTotalTax        *decimal.Decimal       `json:"current_total_tax,omitempty"`
+	TotalWeight            int                    `json:"total_weight,omitempty"`
+	TaxesIncluded          *bool                  `json:"taxes_included,omitempty"`
+	TaxLines               []TaxLine              `json:"tax_lines,omitempty"`
+	LineItems              []LineItem             `json:"line_items,omitempty"`
+	ShippingLines          []ShippingLine         `json:"shipping_lines,omitempty"`
+	DiscountApplications   []DiscountApplication  `json:"discount_applications,omitempty"`
+	DiscountCodes          []DiscountCode         `json:"discount_codes,omitempty"`
+	Refunds                []Refund               `json:"refunds,omitempty"`
+	Fulfillments           []Fulfillment          `json:"fulfillments,omitempty"`
+	BillingAddress         *Address               `json:"billing_address,omitempty"`
+	ShippingAddress        *Address               `json:"shipping_address,omitempty"`
+	Customer               *Customer              `json:"customer,omitempty"`
+	ClientDetails          *ClientDetails         `json:"client_details,omitempty"`
+	BrowserIP              string                 `json:"browser_ip,omitempty"`
+	CustomerLocale         string                 `json:"customer_locale,omitempty"`
+	LandingSite            string                 `json:"landing_site,omitempty"`
+	ReferringSite          string                 `json:"referring_site,omitempty"`
+	SourceName             string                 `json:"source_name,omitempty"`
+	PaymentGatewayNames    []string               `json:"payment_gateway_names,omitempty"`
+	OrderStatusURL         string                 `json:"order_status_url,omitempty"`
+	AppID                  int64                  `json:"app_id,omitempty"`
+	LocationID             int64                  `json:"location_id,omitempty"`
+	UserID                 int64                  `json:"user_id,omitempty"`
+	CheckoutID             int64                  `json:"checkout_id,omitempty"`
+	CheckoutToken          string                 `json:"checkout_token,omitempty"`
+	AdminGraphqlAPIID      string                 `json:"admin_graphql_api_id,omitempty"`
+	SendReceipt            bool                   `json:"send_receipt,omitempty"`
+	SendFulfillmentReceipt bool                   `json:"send_fulfillment_receipt,omitempty"`
+	// InventoryBehaviour is only used when creating an order, e.g.
+	// "decrement_obeying_policy"
+	InventoryBehaviour string `json:"inventory_behaviour,omitempty"`
+}
+
+// LineItem represents a line item of an order
+type LineItem struct {
+	ID                  int64                  `json:"id,omitempty"`
+	ProductID           int64                  `json:"product_id,omitempty"`
+	VariantID           int64                  `json:"variant_id,omitempty"`
+	Title               string                 `json:"title,omitempty"`
+	VariantTitle        string                 `json:"variant_title,omitempty"`
+	Name                string                 `json:"name,omitempty"`
+	Sku                 string                 `json:"sku,omitempty"`
+	Vendor              string                 `json:"vendor,omitempty"`
+	Quantity            int                    `json:"quantity,omitempty"`
+	FulfillableQuantity int                    `json:"fulfillable_quantity,omitempty"`
+	Price               *decimal.Decimal       `json:"price,omitempty"`
+	TotalDiscount       *decimal.Decimal       `json:"total_discount,omitempty"`
+	Grams               int                    `json:"grams,omitempty"`
+	FulfillmentService  string                 `json:"fulfillment_service,omitempty"`
+	FulfillmentStatus   OrderFulfillmentStatus `json:"fulfillment_status,omitempty"`
+	RequiresShipping    *bool                  `json:"requires_shipping,omitempty"`
+	Taxable             *bool                  `json:"taxable,omitempty"`
+	GiftCard            *bool                  `json:"gift_card,omitempty"`
+	ProductExists       bool                   `json:"product_exists,omitempty"`
+	Properties          []NoteAttribute        `json:"properties,omitempty"`
+	TaxLines            []TaxLine              `json:"tax_lines,omitempty"`
+	DiscountAllocations []DiscountAllocation   `json:"discount_allocations,omitempty"`
+	AdminGraphqlAPIID   string                 `json:"admin_graphql_api_id,omitempty"`
+}
+
+// ShippingLine represents a shipping method of an order
+type ShippingLine struct {
+	ID                            int64                `json:"id,omitempty"`
+	Title                         string               `json:"title,omitempty"`
+	Code                          string               `json:"code,omitempty"`
+	Source                        string               `json:"source,omitempty"`
+	Price                         *decimal.Decimal     `json:"price,omitempty"`
+	DiscountedPrice               *decimal.Decimal     `json:"discounted_price,omitempty"`
+	CarrierIdentifier             string               `json:"carrier_identifier,omitempty"`
+	RequestedFulfillmentServiceID string               `json:"requested_fulfillment_service_id,omitempty"`
+	TaxLines                      []TaxLine            `json:"tax_lines,omitempty"`
+	DiscountAllocations           []DiscountAllocation `json:"discount_allocations,omitempty"`
+}
+
+// TaxLine represents a tax applied to an order, line item or shipping line
+type TaxLine struct {
+	Title         string           `json:"title,omitempty"`
+	Price         *decimal.Decimal `json:"price,omitempty"`
+	Rate          *decimal.Decimal `json:"rate,omitempty"`
+	ChannelLiable bool             `json:"channel_liable,omitempty"`
+}
+
+// DiscountApplication represents a discount applied to an order. The
+// DiscountAllocations of line items and shipping lines refer to it by index.
+type DiscountApplication struct {
+	Type             string           `json:"type,omitempty"`
+	Title            string           `json:"title,omitempty"`
+	Description      string           `json:"description,omitempty"`
+	Code             string           `json:"code,omitempty"`
+	Value            *decimal.Decimal `json:"value,omitempty"`
+	ValueType        string           `json:"value_type,omitempty"`
+	AllocationMethod string           `json:"allocation_method,omitempty"`
+	TargetSelection  string           `json:"target_selection,omitempty"`
+	TargetType       string           `json:"target_type,omitempty"`
+}
+
+// DiscountAllocation is the amount of a discount application allocated to a
+// line item or shipping line
+type DiscountAllocation struct {
+	Amount                   *decimal.Decimal `json:"amount,omitempty"`
+	DiscountApplicationIndex int              `json:"discount_application_index"`
+}
+
+// DiscountCode represents a discount code used on an order
+type DiscountCode struct {
+	Code   string           `json:"code,omitempty"`
+	Amount *decimal.Decimal `json:"amount,omitempty"`
+	Type   string           `json:"type,omitempty"`
+}
+
+// ClientDetails represents the browser of the customer who placed an order
+type ClientDetails struct {
+	AcceptLanguage string `json:"accept_language,omitempty"`
+	BrowserHeight  int    `json:"browser_height,omitempty"`
+	BrowserWidth   int    `json:"browser_width,omitempty"`
+	BrowserIP      string `json:"browser_ip,omitempty"`
+	SessionHash    string `json:"session_hash,omitempty"`
+	UserAgent      string `json:"user_agent,omitempty"`
+}
+
+// NoteAttribute is a name/value pair attached to an order or a line item
+type NoteAttribute struct {
+	Name  string      `json:"name,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// OrderResource represents the result from the orders/X.json endpoint
+type OrderResource struct {
+	Order *Order `json:"order"`
+}
+
+// OrdersResource represents the result from the orders.json endpoint
+type OrdersResource struct {
+	Orders []Order `json:"orders"`
+}
+
+// List orders
+func (s *OrderServiceOp) List(ctx context.Context, options interface{}) ([]Order, error) {
+	orders, _, err := s.ListWithPagination(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// ListWithPagination lists orders and returns the pagination to retrieve the
+// next or previous page.
+func (s *OrderServiceOp) ListWithPagination(ctx context.Context, options interface{}) ([]Order, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", ordersBasePath)
+	resource := new(OrdersResource)
+	pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resource.Orders, pagination, nil
+}
+
+// Count orders
+func (s *OrderServiceOp) Count(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/count.json", ordersBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual order
+func (s *OrderServiceOp) Get(ctx context.Context, orderID int64, options interface{}) (*Order, error) {
+	path := fmt.Sprintf("%s/%d.json", ordersBasePath, orderID)
+	resource := new(OrderResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Order, err
+}
+
+// Create a new order
+func (s *OrderServiceOp) Create(ctx context.Context, order Order) (*Order, error) {
+	path := fmt.Sprintf("%s.json", ordersBasePath)
+	wrappedData := OrderResource{Order: &order}
+	resource := new(OrderResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Order, err
+}
+
+// Update an existing order
+func (s *OrderServiceOp) Update(ctx context.Context, order Order) (*Order, error) {
+	path := fmt.Sprintf("%s/%d.json", ordersBasePath, order.ID)
+	wrappedData := OrderResource{Order: &order}
+	resource := new(OrderResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Order, err
+}
+
+// Delete an existing order
+func (s *OrderServiceOp) Delete(ctx context.Context, orderID int64) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", ordersBasePath, orderID))
+}
+
+// Close an order
+func (s *OrderServiceOp) Close(ctx context.Context, orderID int64) (*Order, error) {
+	path := fmt.Sprintf("%s/%d/close.json", ordersBasePath, orderID)
+	resource := new(OrderResource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
+	return resource.Order, err
+}
+
+// Open re-opens a closed order
+func (s *OrderServiceOp) Open(ctx context.Context, orderID int64) (*Order, error) {
+	path := fmt.Sprintf("%s/%d/open.json", ordersBasePath, orderID)
+	resource := new(OrderResource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
+	return resource.Order, err
+}
+
+// Cancel an order, options are usually OrderCancelOptions
+func (s *OrderServiceOp) Cancel(ctx context.Context, orderID int64, options interface{}) (*Order, error) {
+	path := fmt.Sprintf("%s/%d/cancel.json", ordersBasePath, orderID)
+	resource := new(OrderResource)
+	err := s.client.PostWithContext(ctx, path, options, resource)
+	return resource.Order, err
+}
+
+// ListFulfillments for an order
+func (s *OrderServiceOp) ListFulfillments(ctx context.Context, orderID int64, options interface{}) ([]Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.List(ctx, options)
+}
+
+// CountFulfillments for an order
+func (s *OrderServiceOp) CountFulfillments(ctx context.Context, orderID int64, options interface{}) (int, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.Count(ctx, options)
+}
+
+// GetFulfillment for an order
+func (s *OrderServiceOp) GetFulfillment(ctx context.Context, orderID int64, fulfillmentID int64, options interface{}) (*Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.Get(ctx, fulfillmentID, options)
+}
+
+// CreateFulfillment for an order
+func (s *OrderServiceOp) CreateFulfillment(ctx context.Context, orderID int64, fulfillment Fulfillment) (*Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.Create(ctx, fulfillment)
+}
+
+// UpdateFulfillment for an order
+func (s *OrderServiceOp) UpdateFulfillment(ctx context.Context, orderID int64, fulfillment Fulfillment) (*Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.Update(ctx, fulfillment)
+}
+
+// CompleteFulfillment for an order
+func (s *OrderServiceOp) CompleteFulfillment(ctx context.Context, orderID int64, fulfillmentID int64) (*Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.Complete(ctx, fulfillmentID)
+}
+
+// TransitionFulfillment for an order
+func (s *OrderServiceOp) TransitionFulfillment(ctx context.Context, orderID int64, fulfillmentID int64) (*Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.Transition(ctx, fulfillmentID)
+}
+
+// CancelFulfillment for an order
+func (s *OrderServiceOp) CancelFulfillment(ctx context.Context, orderID int64, fulfillmentID int64) (*Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.Cancel(ctx, fulfillmentID)
+}
+
+// UpdateFulfillmentTracking for an order
+func (s *OrderServiceOp) UpdateFulfillmentTracking(ctx context.Context, orderID int64, fulfillmentID int64, info FulfillmentTrackingInfo, notifyCustomer bool) (*Fulfillment, error) {
+	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return fulfillmentService.UpdateTracking(ctx, fulfillmentID, info, notifyCustomer)
+}
+
+// ListMetafields for a order
+func (s *OrderServiceOp) ListMetafields(ctx context.Context, orderID int64, options interface{}) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return metafieldService.List(ctx, options)
+}
+
+// CountMetafields for a order
+func (s *OrderServiceOp) CountMetafields(ctx context.Context, orderID int64, options interface{}) (int, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return metafieldService.Count(ctx, options)
+}
+
+// GetMetafield for a order
+func (s *OrderServiceOp) GetMetafield(ctx context.Context, orderID int64, metafieldID int64, options interface{}) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return metafieldService.Get(ctx, metafieldID, options)
+}
+
+// CreateMetafield for a order
+func (s *OrderServiceOp) CreateMetafield(ctx context.Context, orderID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return metafieldService.Create(ctx, metafield)
+}
+
+// UpdateMetafield for a order
+func (s *OrderServiceOp) UpdateMetafield(ctx context.Context, orderID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return metafieldService.Update(ctx, metafield)
+}
+
+// DeleteMetafield for a order
+func (s *OrderServiceOp) DeleteMetafield(ctx context.Context, orderID int64, metafieldID int64) error {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersBasePath, resourceID: orderID}
+	return metafieldService.Delete(ctx, metafieldID)
+}
@@ -0,0 +1,434 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func orderTests(t *testing.T, order Order) {
+	// Check that ID is assigned to the returned order
+	var expectedInt int64 = 450789469
+	if order.ID != expectedInt {
+		t.Errorf("Order.ID returned %+v, expected %+v", order.ID, expectedInt)
+	}
+
+	cases := []struct {
+		field    string
+		actual   string
+		expected string
+	}{
+		{"Name", order.Name, "#1001"},
+		{"Email", order.Email, "bob.norman@mail.example.com"},
+		{"Currency", order.Currency, "USD"},
+		{"FinancialStatus", string(order.FinancialStatus), string(OrderFinancialStatusPartiallyRefunded)},
+		{"FulfillmentStatus", string(order.FulfillmentStatus), ""},
+		{"SourceName", order.SourceName, "web"},
+		{"AdminGraphqlAPIID", order.AdminGraphqlAPIID, "gid://shopify/Order/450789469"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("Order.%s returned %+v, expected %+v", c.field, c.actual, c.expected)
+		}
+	}
+
+	expectedTime := time.Date(2008, time.January, 10, 16, 0, 0, 0, time.UTC)
+	if order.ProcessedAt == nil || !order.ProcessedAt.Equal(expectedTime) {
+		t.Errorf("Order.ProcessedAt returned %+v, expected %+v", order.ProcessedAt, expectedTime)
+	}
+
+	expectedTotal := decimal.RequireFromString("598.94")
+	if order.TotalPrice == nil || !order.TotalPrice.Equal(expectedTotal) {
+		t.Errorf("Order.TotalPrice returned %v, expected %v", order.TotalPrice, expectedTotal)
+	}
+
+	expectedNoteAttributes := []NoteAttribute{
+		{Name: "custom engraving", Value: "Happy Birthday"},
+		{Name: "colour", Value: "green"},
+	}
+	if !reflect.DeepEqual(order.NoteAttributes, expectedNoteAttributes) {
+		t.Errorf("Order.NoteAttributes returned %+v, expected %+v", order.NoteAttributes, expectedNoteAttributes)
+	}
+
+	expectedAddress := &Address{
+		FirstName:    "Bob",
+		LastName:     "Norman",
+		Name:         "Bob Norman",
+		Address1:     "Chestnut Street 92",
+		City:         "Louisville",
+		Province:     "Kentucky",
+		ProvinceCode: "KY",
+		Country:      "United States",
+		CountryCode:  "US",
+		Zip:          "40202",
+		Phone:        "+1(502)-459-2181",
+		Latitude:     45.41634,
+		Longitude:    -75.6868,
+	}
+	if !reflect.DeepEqual(order.BillingAddress, expectedAddress) {
+		t.Errorf("Order.BillingAddress returned %+v, expected %+v", order.BillingAddress, expectedAddress)
+	}
+	if !reflect.DeepEqual(order.ShippingAddress, expectedAddress) {
+		t.Errorf("Order.ShippingAddress returned %+v, expected %+v", order.ShippingAddress, expectedAddress)
+	}
+
+	if order.ClientDetails == nil || order.ClientDetails.BrowserIP != "0.0.0.0" {
+		t.Errorf("Order.ClientDetails returned %+v", order.ClientDetails)
+	}
+
+	if len(order.TaxLines) != 1 || order.TaxLines[0].Title != "State Tax" || !order.TaxLines[0].Rate.Equal(decimal.RequireFromString("0.06")) {
+		t.Errorf("Order.TaxLines returned %+v", order.TaxLines)
+	}
+
+	if len(order.DiscountApplications) != 1 || order.DiscountApplications[0].Code != "TENOFF" || !order.DiscountApplications[0].Value.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Order.DiscountApplications returned %+v", order.DiscountApplications)
+	}
+
+	if len(order.DiscountCodes) != 1 || order.DiscountCodes[0].Code != "TENOFF" {
+		t.Errorf("Order.DiscountCodes returned %+v", order.DiscountCodes)
+	}
+
+	if len(order.LineItems) != 1 {
+		t.Fatalf("Order.LineItems returned %d line items, expected 1", len(order.LineItems))
+	}
+
+	lineItem := order.LineItems[0]
+	if lineItem.ID != 466157049 || lineItem.ProductID != 632910392 || lineItem.VariantID != 39072856 || lineItem.Quantity != 1 {
+		t.Errorf("Order.LineItems[0] returned %+v", lineItem)
+	}
+	if lineItem.Price == nil || !lineItem.Price.Equal(decimal.NewFromInt(199)) {
+		t.Errorf("Order.LineItems[0].Price returned %v, expected 199", lineItem.Price)
+	}
+	if len(lineItem.Properties) != 1 || lineItem.Properties[0].Name != "Custom Engraving Front" {
+		t.Errorf("Order.LineItems[0].Properties returned %+v", lineItem.Properties)
+	}
+	if len(lineItem.TaxLines) != 1 || !lineItem.TaxLines[0].Price.Equal(decimal.RequireFromString("3.98")) {
+		t.Errorf("Order.LineItems[0].TaxLines returned %+v", lineItem.TaxLines)
+	}
+	expectedAllocation := decimal.RequireFromString("3.34")
+	if len(lineItem.DiscountAllocations) != 1 || !lineItem.DiscountAllocations[0].Amount.Equal(expectedAllocation) || lineItem.DiscountAllocations[0].DiscountApplicationIndex != 0 {
+		t.Errorf("Order.LineItems[0].DiscountAllocations returned %+v", lineItem.DiscountAllocations)
+	}
+
+	if lineItem.RequiresShipping == nil || !*lineItem.RequiresShipping || lineItem.Taxable == nil || !*lineItem.Taxable || lineItem.GiftCard == nil || *lineItem.GiftCard {
+		t.Errorf("Order.LineItems[0].RequiresShipping/Taxable/GiftCard returned %v/%v/%v, expected true/true/false", lineItem.RequiresShipping, lineItem.Taxable, lineItem.GiftCard)
+	}
+
+	if len(order.ShippingLines) != 1 || order.ShippingLines[0].ID != 369256396 || order.ShippingLines[0].Title != "Free Shipping" || !order.ShippingLines[0].Price.IsZero() {
+		t.Errorf("Order.ShippingLines returned %+v", order.ShippingLines)
+	}
+}
+
+func TestOrderList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("orders.json")))
+
+	orders, err := client.Order.List(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Order.List returned error: %v", err)
+	}
+
+	if len(orders) != 2 {
+		t.Fatalf("Order.List returned %d orders, expected 2", len(orders))
+	}
+
+	if orders[0].ID != 450789469 || orders[0].FinancialStatus != OrderFinancialStatusPartiallyRefunded {
+		t.Errorf("Order.List returned %+v", orders[0])
+	}
+
+	if orders[1].FulfillmentStatus != OrderFulfillmentStatusFulfilled || !orders[1].TotalPrice.Equal(decimal.RequireFromString("19.99")) {
+		t.Errorf("Order.List returned %+v", orders[1])
+	}
+}
+
+func TestOrderListFilter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{
+		"status":             "any",
+		"financial_status":   "paid",
+		"fulfillment_status": "unshipped",
+		"processed_at_min":   "2021-01-01T00:00:00Z",
+		"processed_at_max":   "2021-02-01T00:00:00Z",
+		"attribution_app_id": "current",
+		"limit":              "50",
+	}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"orders": [{"id":1},{"id":2}]}`))
+
+	options := OrderListOptions{
+		ListOptions:       ListOptions{Limit: 50},
+		Status:            OrderStatusAny,
+		FinancialStatus:   OrderFinancialStatusPaid,
+		FulfillmentStatus: OrderFulfillmentStatusUnshipped,
+		ProcessedAtMin:    time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ProcessedAtMax:    time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC),
+		AttributionAppID:  "current",
+	}
+
+	orders, err := client.Order.List(context.Background(), options)
+	if err != nil {
+		t.Errorf("Order.List returned error: %v", err)
+	}
+
+	expected := []Order{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(orders, expected) {
+		t.Errorf("Order.List returned %+v, expected %+v", orders, expected)
+	}
+}
+
+func TestOrderListWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	response := &http.Response{
+		StatusCode: 200,
+		Body:       httpmock.NewRespBodyFromString(`{"orders": [{"id":1}]}`),
+		Header: http.Header{
+			"Link": {`<http://valid.url?page_info=foo&limit=1>; rel="next"`},
+		},
+	}
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.ResponderFromResponse(response))
+
+	orders, pagination, err := client.Order.ListWithPagination(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Order.ListWithPagination returned error: %v", err)
+	}
+
+	expected := []Order{{ID: 1}}
+	if !reflect.DeepEqual(orders, expected) {
+		t.Errorf("Order.ListWithPagination orders returned %+v, expected %+v", orders, expected)
+	}
+
+	expectedPagination := &Pagination{NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 1}}
+	if !reflect.DeepEqual(pagination, expectedPagination) {
+		t.Errorf("Order.ListWithPagination pagination returned %+v, expected %+v", pagination, expectedPagination)
+	}
+}
+
+func TestOrderCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 7}`))
+
+	params := map[string]string{"status": "closed"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/count.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"count": 2}`))
+
+	cnt, err := client.Order.Count(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Order.Count returned error: %v", err)
+	}
+
+	expected := 7
+	if cnt != expected {
+		t.Errorf("Order.Count returned %d, expected %d", cnt, expected)
+	}
+
+	cnt, err = client.Order.Count(context.Background(), OrderListOptions{Status: OrderStatusClosed})
+	if err != nil {
+		t.Errorf("Order.Count returned error: %v", err)
+	}
+
+	expected = 2
+	if cnt != expected {
+		t.Errorf("Order.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestOrderGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("order.json")))
+
+	order, err := client.Order.Get(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	orderTests(t, *order)
+}
+
+func TestOrderCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(201, loadFixture("order.json")), nil
+		})
+
+	price := decimal.RequireFromString("199.00")
+	order := Order{
+		Email:              "bob.norman@mail.example.com",
+		LineItems:          []LineItem{{VariantID: 39072856, Quantity: 1, Price: &price}},
+		InventoryBehaviour: "decrement_obeying_policy",
+	}
+
+	returnedOrder, err := client.Order.Create(context.Background(), order)
+	if err != nil {
+		t.Fatalf("Order.Create returned error: %v", err)
+	}
+
+	orderTests(t, *returnedOrder)
+
+	if sent["order"]["inventory_behaviour"] != "decrement_obeying_policy" {
+		t.Errorf("Order.Create sent %+v", sent)
+	}
+
+	lineItems, _ := sent["order"]["line_items"].([]interface{})
+	if len(lineItems) != 1 || lineItems[0].(map[string]interface{})["price"] != "199" {
+		t.Errorf("Order.Create sent line items %+v", lineItems)
+	}
+}
+
+func TestOrderUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("order.json")))
+
+	order := Order{
+		ID:   450789469,
+		Note: "Customer contacted us about a custom engraving on this iPod",
+	}
+
+	returnedOrder, err := client.Order.Update(context.Background(), order)
+	if err != nil {
+		t.Fatalf("Order.Update returned error: %v", err)
+	}
+
+	orderTests(t, *returnedOrder)
+}
+
+func TestOrderUpdateFalse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(b, &sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("order.json")), nil
+		})
+
+	no := false
+	_, err := client.Order.Update(context.Background(), Order{
+		ID:                    450789469,
+		BuyerAcceptsMarketing: &no,
+		TaxesIncluded:         &no,
+	})
+	if err != nil {
+		t.Fatalf("Order.Update returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":                      float64(450789469),
+		"buyer_accepts_marketing": false,
+		"taxes_included":          false,
+	}
+	if !reflect.DeepEqual(sent["order"], expected) {
+		t.Errorf("Order.Update sent %+v, expected %+v", sent["order"], expected)
+	}
+}
+
+func TestOrderDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Order.Delete(context.Background(), 1)
+	if err != nil {
+		t.Errorf("Order.Delete returned error: %v", err)
+	}
+}
+
+func TestOrderClose(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/close.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("order.json")))
+
+	order, err := client.Order.Close(context.Background(), 450789469)
+	if err != nil {
+		t.Fatalf("Order.Close returned error: %v", err)
+	}
+
+	orderTests(t, *order)
+}
+
+func TestOrderOpen(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/open.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("order.json")))
+
+	order, err := client.Order.Open(context.Background(), 450789469)
+	if err != nil {
+		t.Fatalf("Order.Open returned error: %v", err)
+	}
+
+	orderTests(t, *order)
+}
+
+func TestOrderCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/cancel.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, loadFixture("order.json")), nil
+		})
+
+	options := OrderCancelOptions{
+		Reason: OrderCancelReasonCustomer,
+		Email:  true,
+	}
+
+	order, err := client.Order.Cancel(context.Background(), 450789469, options)
+	if err != nil {
+		t.Fatalf("Order.Cancel returned error: %v", err)
+	}
+
+	orderTests(t, *order)
+
+	expected := map[string]interface{}{"reason": "customer", "email": true}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("Order.Cancel sent %+v, expected %+v", sent, expected)
+	}
+}
@@ -0,0 +1,174 @@
+package go_shopify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// a single entry of the Link header, e.g. <https://...?page_info=abc>; rel="next"
+	linkRegex = regexp.MustCompile(`^ *<([^>]+)>; rel="(previous|next)" *$`)
+)
+
+// Pagination holds the options needed to request the pages surrounding the
+// current one. Either field is nil when there is no such page.
+// See: https://shopify.dev/api/usage/pagination-rest
+type Pagination struct {
+	NextPageOptions     *ListOptions
+	PreviousPageOptions *ListOptions
+}
+
+// HasNextPage reports whether there is a page after the current one.
+func (p *Pagination) HasNextPage() bool {
+	return p != nil && p.NextPageOptions != nil
+}
+
+// HasPreviousPage reports whether there is a page before the current one.
+func (p *Pagination) HasPreviousPage() bool {
+	return p != nil && p.PreviousPageOptions != nil
+}
+
+// ListWithPagination performs a GET request for the given path, saves the
+// result in the given resource and returns the pagination info parsed from the
+// Link header of the response.
+func (c *Client) ListWithPagination(ctx context.Context, path string, resource, options interface{}) (*Pagination, error) {
+	response, err := c.createAndDoGetResponse(ctx, "GET", path, nil, options, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractPagination(response.Header)
+}
+
+// extractPagination parses the Link header of a response into a Pagination.
+func extractPagination(headers http.Header) (*Pagination, error) {
+	pagination := new(Pagination)
+
+	linkHeader := headers.Get("Link")
+	if linkHeader == "" {
+		return pagination, nil
+	}
+
+	for _, link := range strings.Split(linkHeader, ",") {
+		match := linkRegex.FindStringSubmatch(link)
+		// Make sure the link is not empty or invalid
+		if len(match) != 3 {
+			// We expect 3 values:
+			// match[0] = full match
+			// match[1] is the URL and match[2] is either 'previous' or 'next'
+			return nil, ResponseDecodingError{
+				Message: "could not extract pagination link header",
+			}
+		}
+
+		rel, err := url.Parse(match[1])
+		if err != nil {
+			return nil, ResponseDecodingError{
+				Message: "pagination does not contain a valid URL",
+			}
+		}
+
+		params, err := url.ParseQuery(rel.RawQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		paginationListOptions := ListOptions{}
+
+		paginationListOptions.PageInfo = params.Get("page_info")
+		if paginationListOptions.PageInfo == "" {
+			return nil, ResponseDecodingError{
+				Message: "page_info is missing",
+			}
+		}
+
+		limit := params.Get("limit")
+		if limit != "" {
+			paginationListOptions.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		paginationListOptions.Fields = params.Get("fields")
+
+		// 'rel' is either next or previous
+		if match[2] == "next" {
+			pagination.NextPageOptions = &paginationListOptions
+		} else {
+			pagination.PreviousPageOptions = &paginationListOptions
+		}
+	}
+
+	return pagination, nil
+}
+
+// PageIterator walks every page of a paginated collection, following the
+// next links returned by Shopify. It is used like a bufio.Scanner:
+//
+//	it := client.NewPageIterator("products.json", ListOptions{Limit: 250})
+//	for {
+//		page := new(struct {
+//			Products []Product `json:"products"`
+//		})
+//		if !it.Next(ctx, page) {
+//			break
+//		}
+//		// use page.Products
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type PageIterator struct {
+	client     *Client
+	path       string
+	options    interface{}
+	pagination *Pagination
+	started    bool
+	err        error
+}
+
+// NewPageIterator returns a PageIterator for the collection at path. The
+// options are only sent with the first request, later pages use the options
+// from the Link header.
+func (c *Client) NewPageIterator(path string, options interface{}) *PageIterator {
+	return &PageIterator{
+		client:  c,
+		path:    path,
+		options: options,
+	}
+}
+
+// Next fetches the next page into resource. It returns false when there are
+// no more pages or an error occurred, see Err.
+func (it *PageIterator) Next(ctx context.Context, resource interface{}) bool {
+	if it.err != nil {
+		return false
+	}
+
+	options := it.options
+	if it.started {
+		if !it.pagination.HasNextPage() {
+			return false
+		}
+		options = it.pagination.NextPageOptions
+	}
+
+	it.started = true
+	it.pagination, it.err = it.client.ListWithPagination(ctx, it.path, resource, options)
+	return it.err == nil
+}
+
+// Err returns the first error encountered while iterating.
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// Pagination returns the pagination info of the last fetched page.
+func (it *PageIterator) Pagination() *Pagination {
+	return it.pagination
+}
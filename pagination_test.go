@@ -0,0 +1,190 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestExtractPagination(t *testing.T) {
+	cases := []struct {
+		linkHeader string
+		expected   *Pagination
+		expectErr  bool
+	}{
+		{
+			"",
+			new(Pagination),
+			false,
+		},
+		{
+			`<https://fooshop.myshopify.com/admin/api/2019-10/products.json?page_info=abc&limit=10>; rel="next"`,
+			&Pagination{
+				NextPageOptions: &ListOptions{PageInfo: "abc", Limit: 10},
+			},
+			false,
+		},
+		{
+			`<https://fooshop.myshopify.com/admin/api/2019-10/products.json?page_info=abc&limit=10&fields=id>; rel="previous", <https://fooshop.myshopify.com/admin/api/2019-10/products.json?page_info=def&limit=10&fields=id>; rel="next"`,
+			&Pagination{
+				PreviousPageOptions: &ListOptions{PageInfo: "abc", Limit: 10, Fields: "id"},
+				NextPageOptions:     &ListOptions{PageInfo: "def", Limit: 10, Fields: "id"},
+			},
+			false,
+		},
+		{
+			`invalid link`,
+			nil,
+			true,
+		},
+		{
+			`<:/shopify.com/?page_info=abc>; rel="next"`,
+			nil,
+			true,
+		},
+		{
+			`<https://fooshop.myshopify.com/products.json?limit=10>; rel="next"`,
+			nil,
+			true,
+		},
+		{
+			`<https://fooshop.myshopify.com/products.json?page_info=abc&limit=invalid>; rel="next"`,
+			nil,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		headers := http.Header{}
+		if c.linkHeader != "" {
+			headers.Set("Link", c.linkHeader)
+		}
+
+		pagination, err := extractPagination(headers)
+		if c.expectErr {
+			if err == nil {
+				t.Errorf("extractPagination(%s): expected error, got nil", c.linkHeader)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("extractPagination(%s) returned error: %v", c.linkHeader, err)
+		}
+
+		if !reflect.DeepEqual(pagination, c.expected) {
+			t.Errorf("extractPagination(%s): expected %+v, actual %+v", c.linkHeader, c.expected, pagination)
+		}
+	}
+}
+
+func TestListWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/foos.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL, createResponderWithHeaders(200,
+		`{"foos": [{"id": 1}]}`,
+		map[string]string{
+			"Link": fmt.Sprintf(`<%s?page_info=abc&limit=1>; rel="next"`, listURL),
+		}))
+
+	resource := struct {
+		Foos []struct {
+			ID int64 `json:"id"`
+		} `json:"foos"`
+	}{}
+
+	pagination, err := client.ListWithPagination(context.Background(), "foos.json", &resource, nil)
+	if err != nil {
+		t.Fatalf("Client.ListWithPagination returned error: %v", err)
+	}
+
+	if len(resource.Foos) != 1 || resource.Foos[0].ID != 1 {
+		t.Errorf("Client.ListWithPagination returned %+v", resource)
+	}
+
+	expected := &Pagination{NextPageOptions: &ListOptions{PageInfo: "abc", Limit: 1}}
+	if !reflect.DeepEqual(pagination, expected) {
+		t.Errorf("Client.ListWithPagination pagination: expected %+v, actual %+v", expected, pagination)
+	}
+
+	if !pagination.HasNextPage() || pagination.HasPreviousPage() {
+		t.Errorf("Client.ListWithPagination pagination: unexpected HasNextPage/HasPreviousPage")
+	}
+}
+
+func TestPageIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/foos.json", client.pathPrefix)
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"limit": "1"},
+		createResponderWithHeaders(200, `{"foos": [{"id": 1}]}`, map[string]string{
+			"Link": fmt.Sprintf(`<%s?page_info=page2&limit=1>; rel="next"`, listURL),
+		}))
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"limit": "1", "page_info": "page2"},
+		createResponderWithHeaders(200, `{"foos": [{"id": 2}]}`, map[string]string{
+			"Link": fmt.Sprintf(`<%s?page_info=page1&limit=1>; rel="previous", <%s?page_info=page3&limit=1>; rel="next"`, listURL, listURL),
+		}))
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"limit": "1", "page_info": "page3"},
+		createResponderWithHeaders(200, `{"foos": [{"id": 3}]}`, map[string]string{
+			"Link": fmt.Sprintf(`<%s?page_info=page2&limit=1>; rel="previous"`, listURL),
+		}))
+
+	var ids []int64
+	it := client.NewPageIterator("foos.json", ListOptions{Limit: 1})
+	for {
+		page := struct {
+			Foos []struct {
+				ID int64 `json:"id"`
+			} `json:"foos"`
+		}{}
+		if !it.Next(context.Background(), &page) {
+			break
+		}
+		for _, foo := range page.Foos {
+			ids = append(ids, foo.ID)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("PageIterator returned error: %v", err)
+	}
+
+	expected := []int64{1, 2, 3}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("PageIterator returned %v, expected %v", ids, expected)
+	}
+
+	if it.Pagination().HasNextPage() {
+		t.Errorf("PageIterator last page should not have a next page")
+	}
+}
+
+func TestPageIteratorError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/foos.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"error": "does not exist"}`))
+
+	it := client.NewPageIterator("foos.json", nil)
+	if it.Next(context.Background(), nil) {
+		t.Errorf("PageIterator.Next returned true, expected false")
+	}
+
+	expected := ResponseError{Status: 404, Message: "does not exist"}
+	if !reflect.DeepEqual(it.Err(), expected) {
+		t.Errorf("PageIterator.Err returned %#v, expected %#v", it.Err(), expected)
+	}
+
+	if it.Next(context.Background(), nil) {
+		t.Errorf("PageIterator.Next after error returned true, expected false")
+	}
+}
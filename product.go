@@ -0,0 +1,199 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const productsBasePath = "products"
+
+// ProductService is an interface for interfacing with the product endpoints
+// of the Shopify API.
+// See: https://help.shopify.com/api/reference/product
+type ProductService interface {
+	List(context.Context, interface{}) ([]Product, error)
+	ListWithPagination(context.Context, interface{}) ([]Product, *Pagination, error)
+	Count(context.Context, interface{}) (int, error)
+	Get(context.Context, int64, interface{}) (*Product, error)
+	Create(context.Context, Product) (*Product, error)
+	Update(context.Context, Product) (*Product, error)
+	Delete(context.Context, int64) error
+
+	// MetafieldsService used for Product resource to communicate with Metafields resource
+	MetafieldsService
+}
+
+// ProductServiceOp handles communication with the product related methods of
+// the Shopify API.
+type ProductServiceOp struct {
+	client *Client
+}
+
+// ProductStatus represents the status of a product
+type ProductStatus string
+
+const (
+	ProductStatusActive   ProductStatus = "active"
+	ProductStatusArchived ProductStatus = "archived"
+	ProductStatusDraft    ProductStatus = "draft"
+)
+
+// ProductPublishedScope is where a product is published
+type ProductPublishedScope string
+
+const (
+	// only on the online store channel
+	ProductPublishedScopeWeb ProductPublishedScope = "web"
+	// on the online store channel and the point of sale channel
+	ProductPublishedScopeGlobal ProductPublishedScope = "global"
+)
+
+// Product represents a Shopify product
+type Product struct {
+	ID                             int64                 `json:"id,omitempty"`
+	Title                          string                `json:"title,omitempty"`
+	BodyHTML                       string                `json:"body_html,omitempty"`
+	Vendor                         string                `json:"vendor,omitempty"`
+	ProductType                    string                `json:"product_type,omitempty"`
+	Handle                         string                `json:"handle,omitempty"`
+	CreatedAt                      *time.Time            `json:"created_at,omitempty"`
+	UpdatedAt                      *time.Time            `json:"updated_at,omitempty"`
+	PublishedAt                    *time.Time            `json:"published_at,omitempty"`
+	PublishedScope                 ProductPublishedScope `json:"published_scope,omitempty"`
+	Tags                           string                `json:"tags,omitempty"`
+	Status                         ProductStatus         `json:"status,omitempty"`
+	Options                        []ProductOption       `json:"options,omitempty"`
+	Variants                       []Variant             `json:"variants,omitempty"`
+	Image                          *Image                `json:"image,omitempty"`
+	Images                         []Image               `json:"images,omitempty"`
+	TemplateSuffix                 string                `json:"template_suffix,omitempty"`
+	MetafieldsGlobalTitleTag       string                `json:"metafields_global_title_tag,omitempty"`
+	MetafieldsGlobalDescriptionTag string                `json:"metafields_global_description_tag,omitempty"`
+	AdminGraphqlAPIID              string                `json:"admin_graphql_api_id,omitempty"`
+}
+
+// ProductOption represents a Shopify product option, e.g. size or color
+type ProductOption struct {
+	ID        int64    `json:"id,omitempty"`
+	ProductID int64    `json:"product_id,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Position  int      `json:"position,omitempty"`
+	Values    []string `json:"values,omitempty"`
+}
+
+// ProductListOptions are the options for listing and counting products
+type ProductListOptions struct {
+	ListOptions
+	CollectionID    int64           `url:"collection_id,omitempty"`
+	ProductType     string          `url:"product_type,omitempty"`
+	Handle          string          `url:"handle,omitempty"`
+	Status          []ProductStatus `url:"status,omitempty,comma"`
+	PublishedStatus string          `url:"published_status,omitempty"`
+	PublishedAtMin  time.Time       `url:"published_at_min,omitempty"`
+	PublishedAtMax  time.Time       `url:"published_at_max,omitempty"`
+}
+
+// ProductResource is the result from the products/X.json endpoint
+type ProductResource struct {
+	Product *Product `json:"product"`
+}
+
+// ProductsResource is the result from the products.json endpoint
+type ProductsResource struct {
+	Products []Product `json:"products"`
+}
+
+// List products
+func (s *ProductServiceOp) List(ctx context.Context, options interface{}) ([]Product, error) {
+	products, _, err := s.ListWithPagination(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// ListWithPagination lists products and returns the pagination to retrieve
+// the next or previous page.
+func (s *ProductServiceOp) ListWithPagination(ctx context.Context, options interface{}) ([]Product, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", productsBasePath)
+	resource := new(ProductsResource)
+	pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resource.Products, pagination, nil
+}
+
+// Count products
+func (s *ProductServiceOp) Count(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/count.json", productsBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual product
+func (s *ProductServiceOp) Get(ctx context.Context, productID int64, options interface{}) (*Product, error) {
+	path := fmt.Sprintf("%s/%d.json", productsBasePath, productID)
+	resource := new(ProductResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Product, err
+}
+
+// Create a new product
+func (s *ProductServiceOp) Create(ctx context.Context, product Product) (*Product, error) {
+	path := fmt.Sprintf("%s.json", productsBasePath)
+	wrappedData := ProductResource{Product: &product}
+	resource := new(ProductResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Product, err
+}
+
+// Update an existing product
+func (s *ProductServiceOp) Update(ctx context.Context, product Product) (*Product, error) {
+	path := fmt.Sprintf("%s/%d.json", productsBasePath, product.ID)
+	wrappedData := ProductResource{Product: &product}
+	resource := new(ProductResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Product, err
+}
+
+// Delete an existing product
+func (s *ProductServiceOp) Delete(ctx context.Context, productID int64) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", productsBasePath, productID))
+}
+
+// ListMetafields for a product
+func (s *ProductServiceOp) ListMetafields(ctx context.Context, productID int64, options interface{}) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsBasePath, resourceID: productID}
+	return metafieldService.List(ctx, options)
+}
+
+// CountMetafields for a product
+func (s *ProductServiceOp) CountMetafields(ctx context.Context, productID int64, options interface{}) (int, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsBasePath, resourceID: productID}
+	return metafieldService.Count(ctx, options)
+}
+
+// GetMetafield for a product
+func (s *ProductServiceOp) GetMetafield(ctx context.Context, productID int64, metafieldID int64, options interface{}) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsBasePath, resourceID: productID}
+	return metafieldService.Get(ctx, metafieldID, options)
+}
+
+// CreateMetafield for a product
+func (s *ProductServiceOp) CreateMetafield(ctx context.Context, productID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsBasePath, resourceID: productID}
+	return metafieldService.Create(ctx, metafield)
+}
+
+// UpdateMetafield for a product
+func (s *ProductServiceOp) UpdateMetafield(ctx context.Context, productID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsBasePath, resourceID: productID}
+	return metafieldService.Update(ctx, metafield)
+}
+
+// DeleteMetafield for a product
+func (s *ProductServiceOp) DeleteMetafield(ctx context.Context, productID int64, metafieldID int64) error {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsBasePath, resourceID: productID}
+	return metafieldService.Delete(ctx, metafieldID)
+}
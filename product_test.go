@@ -0,0 +1,347 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func productTests(t *testing.T, product Product) {
+	// Check that ID is assigned to the returned product
+	var expectedInt int64 = 632910392
+	if product.ID != expectedInt {
+		t.Errorf("Product.ID returned %+v, expected %+v", product.ID, expectedInt)
+	}
+
+	cases := []struct {
+		field    string
+		actual   string
+		expected string
+	}{
+		{"Title", product.Title, "IPod Nano - 8GB"},
+		{"Vendor", product.Vendor, "Apple"},
+		{"ProductType", product.ProductType, "Cult Products"},
+		{"Handle", product.Handle, "ipod-nano"},
+		{"Tags", product.Tags, "Emotive, Flash Memory, MP3, Music"},
+		{"Status", string(product.Status), string(ProductStatusActive)},
+		{"PublishedScope", string(product.PublishedScope), string(ProductPublishedScopeWeb)},
+		{"TemplateSuffix", product.TemplateSuffix, "special"},
+		{"AdminGraphqlAPIID", product.AdminGraphqlAPIID, "gid://shopify/Product/632910392"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("Product.%s returned %+v, expected %+v", c.field, c.actual, c.expected)
+		}
+	}
+
+	expectedTime := time.Date(2021, time.October, 1, 20, 51, 24, 0, time.UTC)
+	if product.CreatedAt == nil || !product.CreatedAt.Equal(expectedTime) {
+		t.Errorf("Product.CreatedAt returned %+v, expected %+v", product.CreatedAt, expectedTime)
+	}
+
+	expectedOptions := []ProductOption{{
+		ID:        594680422,
+		ProductID: 632910392,
+		Name:      "Color",
+		Position:  1,
+		Values:    []string{"Pink", "Red", "Green", "Black"},
+	}}
+	if !reflect.DeepEqual(product.Options, expectedOptions) {
+		t.Errorf("Product.Options returned %+v, expected %+v", product.Options, expectedOptions)
+	}
+
+	if len(product.Variants) != 1 {
+		t.Fatalf("Product.Variants returned %d variants, expected 1", len(product.Variants))
+	}
+
+	variant := product.Variants[0]
+	expectedPrice := decimal.NewFromFloat(199)
+	if variant.Price == nil || !variant.Price.Equal(expectedPrice) {
+		t.Errorf("Product.Variants[0].Price returned %v, expected %v", variant.Price, expectedPrice)
+	}
+	if variant.CompareAtPrice != nil {
+		t.Errorf("Product.Variants[0].CompareAtPrice returned %v, expected nil", variant.CompareAtPrice)
+	}
+	if variant.Sku != "IPOD2008PINK" || variant.InventoryItemID != 808950810 {
+		t.Errorf("Product.Variants[0] returned %+v", variant)
+	}
+
+	if len(product.Images) != 1 || product.Image == nil || product.Image.ID != 850703190 {
+		t.Errorf("Product.Images returned %+v, Product.Image %+v", product.Images, product.Image)
+	}
+}
+
+func TestProductList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("products.json")))
+
+	products, err := client.Product.List(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Product.List returned error: %v", err)
+	}
+
+	expected := []Product{
+		{ID: 632910392, Title: "IPod Nano - 8GB", Handle: "ipod-nano", Status: ProductStatusActive},
+		{ID: 921728736, Title: "IPod Touch 8GB", Handle: "ipod-touch", Status: ProductStatusDraft},
+	}
+	if !reflect.DeepEqual(products, expected) {
+		t.Errorf("Product.List returned %+v, expected %+v", products, expected)
+	}
+}
+
+func TestProductListFilter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{
+		"collection_id":    "841564295",
+		"product_type":     "Cult Products",
+		"handle":           "ipod-nano",
+		"status":           "active,draft",
+		"published_status": "published",
+		"vendor":           "Apple",
+		"limit":            "50",
+	}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"products": [{"id":1},{"id":2}]}`))
+
+	options := ProductListOptions{
+		ListOptions:     ListOptions{Limit: 50, Vendor: "Apple"},
+		CollectionID:    841564295,
+		ProductType:     "Cult Products",
+		Handle:          "ipod-nano",
+		Status:          []ProductStatus{ProductStatusActive, ProductStatusDraft},
+		PublishedStatus: "published",
+	}
+
+	products, err := client.Product.List(context.Background(), options)
+	if err != nil {
+		t.Errorf("Product.List returned error: %v", err)
+	}
+
+	expected := []Product{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(products, expected) {
+		t.Errorf("Product.List returned %+v, expected %+v", products, expected)
+	}
+}
+
+func TestProductListError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		httpmock.NewStringResponder(500, ""))
+
+	expectedErrMessage := "Unknown Error"
+
+	products, err := client.Product.List(context.Background(), nil)
+	if products != nil {
+		t.Errorf("Product.List returned products, expected nil: %v", err)
+	}
+
+	if err == nil || err.Error() != expectedErrMessage {
+		t.Errorf("Product.List err returned %+v, expected %+v", err, expectedErrMessage)
+	}
+}
+
+func TestProductListWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix)
+
+	cases := []struct {
+		body               string
+		linkHeader         string
+		expectedProducts   []Product
+		expectedPagination *Pagination
+		expectedErr        error
+	}{
+		{
+			`{"products": [{"id":1},{"id":2}]}`,
+			"",
+			[]Product{{ID: 1}, {ID: 2}},
+			new(Pagination),
+			nil,
+		},
+		{
+			`{"products": [{"id":1}]}`,
+			`<http://valid.url?page_info=foo&limit=2>; rel="next"`,
+			[]Product{{ID: 1}},
+			&Pagination{NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 2}},
+			nil,
+		},
+		{
+			`{"products": []}`,
+			"invalid link",
+			nil,
+			nil,
+			ResponseDecodingError{Message: "could not extract pagination link header"},
+		},
+	}
+
+	for i, c := range cases {
+		response := &http.Response{
+			StatusCode: 200,
+			Body:       httpmock.NewRespBodyFromString(c.body),
+			Header: http.Header{
+				"Link": {c.linkHeader},
+			},
+		}
+
+		httpmock.RegisterResponder("GET", listURL, httpmock.ResponderFromResponse(response))
+
+		products, pagination, err := client.Product.ListWithPagination(context.Background(), nil)
+		if !reflect.DeepEqual(products, c.expectedProducts) {
+			t.Errorf("test %d Product.ListWithPagination products returned %+v, expected %+v", i, products, c.expectedProducts)
+		}
+
+		if !reflect.DeepEqual(pagination, c.expectedPagination) {
+			t.Errorf("test %d Product.ListWithPagination pagination returned %+v, expected %+v", i, pagination, c.expectedPagination)
+		}
+
+		if !reflect.DeepEqual(err, c.expectedErr) {
+			t.Errorf("test %d Product.ListWithPagination err returned %+v, expected %+v", i, err, c.expectedErr)
+		}
+	}
+}
+
+func TestProductCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 3}`))
+
+	params := map[string]string{"created_at_min": "2016-01-01T00:00:00Z"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products/count.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"count": 2}`))
+
+	cnt, err := client.Product.Count(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Product.Count returned error: %v", err)
+	}
+
+	expected := 3
+	if cnt != expected {
+		t.Errorf("Product.Count returned %d, expected %d", cnt, expected)
+	}
+
+	date := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cnt, err = client.Product.Count(context.Background(), CountOptions{CreatedAtMin: date})
+	if err != nil {
+		t.Errorf("Product.Count returned error: %v", err)
+	}
+
+	expected = 2
+	if cnt != expected {
+		t.Errorf("Product.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestProductGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("product.json")))
+
+	product, err := client.Product.Get(context.Background(), 632910392, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+
+	productTests(t, *product)
+}
+
+func TestProductCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, loadFixture("product.json")), nil
+		})
+
+	price := decimal.RequireFromString("199.00")
+	product := Product{
+		Title:       "IPod Nano - 8GB",
+		BodyHTML:    "<strong>Good snowboard!</strong>",
+		Vendor:      "Apple",
+		ProductType: "Cult Products",
+		Status:      ProductStatusDraft,
+		Variants:    []Variant{{Option1: "Pink", Price: &price}},
+	}
+
+	returnedProduct, err := client.Product.Create(context.Background(), product)
+	if err != nil {
+		t.Fatalf("Product.Create returned error: %v", err)
+	}
+
+	productTests(t, *returnedProduct)
+
+	if sent["product"]["title"] != "IPod Nano - 8GB" || sent["product"]["status"] != "draft" {
+		t.Errorf("Product.Create sent %+v", sent)
+	}
+
+	// empty fields are not sent
+	if _, ok := sent["product"]["id"]; ok {
+		t.Errorf("Product.Create sent an id: %+v", sent)
+	}
+
+	variants, _ := sent["product"]["variants"].([]interface{})
+	if len(variants) != 1 || variants[0].(map[string]interface{})["price"] != "199" {
+		t.Errorf("Product.Create sent variants %+v", variants)
+	}
+}
+
+func TestProductUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("product.json")))
+
+	product := Product{
+		ID:          632910392,
+		ProductType: "Cult Products",
+	}
+
+	returnedProduct, err := client.Product.Update(context.Background(), product)
+	if err != nil {
+		t.Fatalf("Product.Update returned error: %v", err)
+	}
+
+	productTests(t, *returnedProduct)
+}
+
+func TestProductDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Product.Delete(context.Background(), 1)
+	if err != nil {
+		t.Errorf("Product.Delete returned error: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package go_shopify
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Leaky bucket settings for standard shops: 40 requests, leaking 2 per second
+	DefaultRateLimitBucketSize = 40
+	DefaultRateLimitLeakRate   = 2
+
+	// Leaky bucket settings for Shopify Plus shops: 80 requests, leaking 4 per second
+	PlusRateLimitBucketSize = 80
+	PlusRateLimitLeakRate   = 4
+)
+
+// RateLimiter implements Shopify's leaky bucket algorithm on the client side
+// so requests are delayed before they would be rejected with a 429.
+// See: https://shopify.dev/api/usage/rate-limits
+//
+// The limiter calibrates itself from the X-Shopify-Shop-Api-Call-Limit header
+// of every response. A single RateLimiter is safe for concurrent use and
+// should be shared between all clients talking to the same shop, see
+// WithRateLimiter.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	bucketSize int
+	leakRate   float64 // requests per second
+
+	level float64 // current fill of the bucket
+	last  time.Time
+}
+
+// NewRateLimiter returns a RateLimiter with the given bucket size and leak
+// rate in requests per second, e.g.
+// NewRateLimiter(DefaultRateLimitBucketSize, DefaultRateLimitLeakRate)
+func NewRateLimiter(bucketSize int, leakRate float64) *RateLimiter {
+	return &RateLimiter{
+		bucketSize: bucketSize,
+		leakRate:   leakRate,
+		last:       time.Now(),
+	}
+}
+
+// leak drains the bucket for the time passed since the last call.
+// The caller must hold l.mu.
+func (l *RateLimiter) leak(now time.Time) {
+	l.level -= now.Sub(l.last).Seconds() * l.leakRate
+	if l.level < 0 {
+		l.level = 0
+	}
+	l.last = now
+}
+
+// Wait blocks until there is room in the bucket for one more request, then
+// reserves it. It returns ctx.Err() if ctx is done before that.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		l.leak(time.Now())
+		if l.level+1 <= float64(l.bucketSize) {
+			l.level++
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((l.level + 1 - float64(l.bucketSize)) / l.leakRate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Available returns the number of requests that can be sent right now
+// without waiting.
+func (l *RateLimiter) Available() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.leak(time.Now())
+	return int(float64(l.bucketSize) - l.level)
+}
+
+// calibrate adjusts the bucket from the call limit reported by Shopify. The
+// local level is never lowered because requests still in flight are not yet
+// part of the reported count. A bigger bucket (e.g. Shopify Plus) scales the
+// leak rate along with it.
+func (l *RateLimiter) calibrate(used, size int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.leak(time.Now())
+	if size > 0 && size != l.bucketSize {
+		l.leakRate = l.leakRate * float64(size) / float64(l.bucketSize)
+		l.bucketSize = size
+	}
+	if float64(used) > l.level {
+		l.level = float64(used)
+	}
+}
+
+// fill marks the bucket as full, used when Shopify responded with a 429.
+func (l *RateLimiter) fill() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.leak(time.Now())
+	l.level = float64(l.bucketSize)
+}
+
+// usesRESTBucket reports whether req counts against the leaky bucket of the
+// REST Admin API. GraphQL queries are limited by their cost instead, see
+// graphQLBucket.
+func usesRESTBucket(req *http.Request) bool {
+	return !strings.HasSuffix(req.URL.Path, "/"+graphQLPath)
+}
+
+// parseCallLimit parses the X-Shopify-Shop-Api-Call-Limit header, e.g. "32/40".
+func parseCallLimit(header http.Header) (used, size int, ok bool) {
+	s := strings.Split(header.Get("X-Shopify-Shop-Api-Call-Limit"), "/")
+	if len(s) != 2 {
+		return 0, 0, false
+	}
+
+	used, err := strconv.Atoi(s[0])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	size, err = strconv.Atoi(s[1])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return used, size, true
+}
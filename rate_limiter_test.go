@@ -0,0 +1,151 @@
+package go_shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := NewRateLimiter(2, 20)
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("RateLimiter.Wait returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("RateLimiter.Wait blocked %s with room in the bucket", elapsed)
+	}
+
+	// bucket is full, the next request has to wait for one to leak (50ms)
+	start = time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("RateLimiter.Wait returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("RateLimiter.Wait returned after %s on a full bucket", elapsed)
+	}
+}
+
+func TestRateLimiterWaitContextCancelled(t *testing.T) {
+	limiter := NewRateLimiter(1, 0.1)
+	_ = limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RateLimiter.Wait: expected error %v, actual %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestRateLimiterCalibrate(t *testing.T) {
+	limiter := NewRateLimiter(DefaultRateLimitBucketSize, DefaultRateLimitLeakRate)
+
+	limiter.calibrate(30, DefaultRateLimitBucketSize)
+	if available := limiter.Available(); available != 10 {
+		t.Errorf("RateLimiter.Available returned %d, expected 10", available)
+	}
+
+	// a lower count than the local level is ignored
+	limiter.calibrate(5, DefaultRateLimitBucketSize)
+	if available := limiter.Available(); available != 10 {
+		t.Errorf("RateLimiter.Available returned %d, expected 10", available)
+	}
+
+	// Shopify Plus bucket
+	limiter.calibrate(30, PlusRateLimitBucketSize)
+	if limiter.bucketSize != PlusRateLimitBucketSize {
+		t.Errorf("RateLimiter.bucketSize = %d, expected %d", limiter.bucketSize, PlusRateLimitBucketSize)
+	}
+	if limiter.leakRate != PlusRateLimitLeakRate {
+		t.Errorf("RateLimiter.leakRate = %v, expected %v", limiter.leakRate, PlusRateLimitLeakRate)
+	}
+
+	limiter.fill()
+	if available := limiter.Available(); available != 0 {
+		t.Errorf("RateLimiter.Available returned %d, expected 0", available)
+	}
+}
+
+func TestParseCallLimit(t *testing.T) {
+	cases := []struct {
+		header string
+		used   int
+		size   int
+		ok     bool
+	}{
+		{"15/40", 15, 40, true},
+		{"", 0, 0, false},
+		{"15", 0, 0, false},
+		{"invalid/40", 0, 0, false},
+		{"15/invalid", 0, 0, false},
+	}
+
+	for _, c := range cases {
+		header := http.Header{}
+		header.Set("X-Shopify-Shop-Api-Call-Limit", c.header)
+		used, size, ok := parseCallLimit(header)
+		if used != c.used || size != c.size || ok != c.ok {
+			t.Errorf("parseCallLimit(%s): expected %d, %d, %v, actual %d, %d, %v", c.header, c.used, c.size, c.ok, used, size, ok)
+		}
+	}
+}
+
+func TestClientWithSharedRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(DefaultRateLimitBucketSize, DefaultRateLimitLeakRate)
+
+	clients := []*Client{
+		NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithRateLimiter(limiter)),
+		NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithRateLimiter(limiter)),
+	}
+
+	for i, c := range clients {
+		httpmock.ActivateNonDefault(c.Client)
+		httpmock.RegisterResponder("GET",
+			fmt.Sprintf("https://fooshop.myshopify.com/%s/foo/1", c.pathPrefix),
+			createResponderWithHeaders(200, `{}`, map[string]string{
+				"X-Shopify-Shop-Api-Call-Limit": fmt.Sprintf("%d/40", 20+i),
+			}))
+
+		if err := c.Get("foo/1", nil, nil); err != nil {
+			t.Fatalf("Client.Get returned error: %v", err)
+		}
+	}
+	httpmock.DeactivateAndReset()
+
+	// the second client saw 21 used requests, which includes the first client's
+	if available := limiter.Available(); available != 19 {
+		t.Errorf("RateLimiter.Available returned %d, expected 19", available)
+	}
+}
+
+func TestClientRateLimiterSkipsGraphQL(t *testing.T) {
+	limiter := NewRateLimiter(DefaultRateLimitBucketSize, DefaultRateLimitLeakRate)
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithRateLimiter(limiter))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", testClient.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data": {}}`))
+
+	for i := 0; i < 5; i++ {
+		if err := testClient.GraphQL.Query(context.Background(), "{ shop { name } }", nil, nil); err != nil {
+			t.Fatalf("GraphQL.Query returned error: %v", err)
+		}
+	}
+
+	// graphql queries are limited by their cost, not the REST bucket
+	if available := limiter.Available(); available != DefaultRateLimitBucketSize {
+		t.Errorf("RateLimiter.Available returned %d, expected %d", available, DefaultRateLimitBucketSize)
+	}
+}
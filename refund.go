@@ -0,0 +1,141 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const refundsBasePath = "refunds"
+
+// RefundService is an interface for interfacing with the refund endpoints of
+// the Shopify API.
+// See: https://help.shopify.com/api/reference/refund
+type RefundService interface {
+	List(context.Context, int64, interface{}) ([]Refund, error)
+	Get(context.Context, int64, int64, interface{}) (*Refund, error)
+	Create(context.Context, int64, Refund) (*Refund, error)
+	Calculate(context.Context, int64, Refund) (*Refund, error)
+}
+
+// RefundServiceOp handles communication with the refund related methods of
+// the Shopify API.
+type RefundServiceOp struct {
+	client *Client
+}
+
+// RefundRestockType is how the items of a refund line item are restocked
+type RefundRestockType string
+
+const (
+	// the items are not restocked
+	RefundRestockTypeNoRestock RefundRestockType = "no_restock"
+	// the items were not delivered yet and are restocked at LocationID
+	RefundRestockTypeCancel RefundRestockType = "cancel"
+	// the items were returned and are restocked at LocationID
+	RefundRestockTypeReturn RefundRestockType = "return"
+	// only returned for refunds created before restock types existed
+	RefundRestockTypeLegacyRestock RefundRestockType = "legacy_restock"
+)
+
+// Refund represents a Shopify refund. To refund an order, call Calculate with
+// the shipping and line items to refund, then Create the refund with the
+// transactions of the result after changing their kind to refund.
+type Refund struct {
+	ID                int64             `json:"id,omitempty"`
+	OrderID           int64             `json:"order_id,omitempty"`
+	UserID            int64             `json:"user_id,omitempty"`
+	Note              string            `json:"note,omitempty"`
+	Notify            bool              `json:"notify,omitempty"`
+	Currency          string            `json:"currency,omitempty"`
+	CreatedAt         *time.Time        `json:"created_at,omitempty"`
+	ProcessedAt       *time.Time        `json:"processed_at,omitempty"`
+	Shipping          *RefundShipping   `json:"shipping,omitempty"`
+	RefundLineItems   []RefundLineItem  `json:"refund_line_items,omitempty"`
+	Transactions      []Transaction     `json:"transactions,omitempty"`
+	OrderAdjustments  []OrderAdjustment `json:"order_adjustments,omitempty"`
+	AdminGraphqlAPIID string            `json:"admin_graphql_api_id,omitempty"`
+}
+
+// RefundShipping is the shipping to refund. Either FullRefund or Amount is set
+// on requests, Tax and MaximumRefundable are returned by Calculate.
+type RefundShipping struct {
+	FullRefund        bool             `json:"full_refund,omitempty"`
+	Amount            *decimal.Decimal `json:"amount,omitempty"`
+	Tax               *decimal.Decimal `json:"tax,omitempty"`
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable,omitempty"`
+}
+
+// RefundLineItem represents a line item of an order being refunded
+type RefundLineItem struct {
+	ID          int64             `json:"id,omitempty"`
+	LineItemID  int64             `json:"line_item_id,omitempty"`
+	LineItem    *LineItem         `json:"line_item,omitempty"`
+	Quantity    int               `json:"quantity,omitempty"`
+	RestockType RefundRestockType `json:"restock_type,omitempty"`
+	LocationID  int64             `json:"location_id,omitempty"`
+	Price       *decimal.Decimal  `json:"price,omitempty"`
+	Subtotal    *decimal.Decimal  `json:"subtotal,omitempty"`
+	TotalTax    *decimal.Decimal  `json:"total_tax,omitempty"`
+}
+
+// OrderAdjustment represents a difference between the refunded amount and the
+// refunded items, e.g. refunded shipping
+type OrderAdjustment struct {
+	ID        int64            `json:"id,omitempty"`
+	OrderID   int64            `json:"order_id,omitempty"`
+	RefundID  int64            `json:"refund_id,omitempty"`
+	Kind      string           `json:"kind,omitempty"`
+	Reason    string           `json:"reason,omitempty"`
+	Amount    *decimal.Decimal `json:"amount,omitempty"`
+	TaxAmount *decimal.Decimal `json:"tax_amount,omitempty"`
+}
+
+// RefundResource represents the result from the orders/X/refunds/Y.json endpoint
+type RefundResource struct {
+	Refund *Refund `json:"refund"`
+}
+
+// RefundsResource represents the result from the orders/X/refunds.json endpoint
+type RefundsResource struct {
+	Refunds []Refund `json:"refunds"`
+}
+
+// List refunds of an order
+func (s *RefundServiceOp) List(ctx context.Context, orderID int64, options interface{}) ([]Refund, error) {
+	path := fmt.Sprintf("%s/%d/%s.json", ordersBasePath, orderID, refundsBasePath)
+	resource := new(RefundsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Refunds, err
+}
+
+// Get individual refund of an order
+func (s *RefundServiceOp) Get(ctx context.Context, orderID int64, refundID int64, options interface{}) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/%s/%d.json", ordersBasePath, orderID, refundsBasePath, refundID)
+	resource := new(RefundResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Refund, err
+}
+
+// Create a refund for an order. Failed validations are returned as a
+// ResponseError with FieldErrors.
+func (s *RefundServiceOp) Create(ctx context.Context, orderID int64, refund Refund) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/%s.json", ordersBasePath, orderID, refundsBasePath)
+	wrappedData := RefundResource{Refund: &refund}
+	resource := new(RefundResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Refund, err
+}
+
+// Calculate previews a refund of the given shipping and line items without
+// creating it. The result holds the taxes, the restock details and the
+// transactions of kind suggested_refund to use for Create.
+func (s *RefundServiceOp) Calculate(ctx context.Context, orderID int64, refund Refund) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/%s/calculate.json", ordersBasePath, orderID, refundsBasePath)
+	wrappedData := RefundResource{Refund: &refund}
+	resource := new(RefundResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Refund, err
+}
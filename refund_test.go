@@ -0,0 +1,191 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func refundTests(t *testing.T, refund Refund) {
+	// Check that the ID is assigned to the returned refund
+	var expectedInt int64 = 509562969
+	if refund.ID != expectedInt {
+		t.Errorf("Refund.ID returned %+v, expected %+v", refund.ID, expectedInt)
+	}
+
+	expectedNote := "it broke during shipping"
+	if refund.Note != expectedNote {
+		t.Errorf("Refund.Note returned %+v, expected %+v", refund.Note, expectedNote)
+	}
+
+	if len(refund.RefundLineItems) != 1 {
+		t.Fatalf("Refund.RefundLineItems returned %d items, expected 1", len(refund.RefundLineItems))
+	}
+
+	item := refund.RefundLineItems[0]
+	if item.LineItemID != 703073504 || item.Quantity != 1 || item.RestockType != RefundRestockTypeReturn || item.LocationID != 487838322 {
+		t.Errorf("Refund.RefundLineItems[0] returned %+v", item)
+	}
+	if item.Subtotal == nil || !item.Subtotal.Equal(decimal.RequireFromString("195.66")) {
+		t.Errorf("Refund.RefundLineItems[0].Subtotal returned %v, expected 195.66", item.Subtotal)
+	}
+	if item.LineItem == nil || item.LineItem.ID != 703073504 {
+		t.Errorf("Refund.RefundLineItems[0].LineItem returned %+v", item.LineItem)
+	}
+
+	if len(refund.Transactions) != 1 || refund.Transactions[0].Kind != TransactionKindRefund || refund.Transactions[0].ParentID != 801038806 {
+		t.Errorf("Refund.Transactions returned %+v", refund.Transactions)
+	}
+
+	if len(refund.OrderAdjustments) != 1 || refund.OrderAdjustments[0].Kind != "shipping_refund" || !refund.OrderAdjustments[0].Amount.Equal(decimal.NewFromInt(-5)) {
+		t.Errorf("Refund.OrderAdjustments returned %+v", refund.OrderAdjustments)
+	}
+}
+
+func TestRefundList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"refunds": [{"id":1},{"id":2}]}`))
+
+	refunds, err := client.Refund.List(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Errorf("Refund.List returned error: %v", err)
+	}
+
+	expected := []Refund{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(refunds, expected) {
+		t.Errorf("Refund.List returned %+v, expected %+v", refunds, expected)
+	}
+}
+
+func TestRefundGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds/509562969.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("refund.json")))
+
+	refund, err := client.Refund.Get(context.Background(), 450789469, 509562969, nil)
+	if err != nil {
+		t.Fatalf("Refund.Get returned error: %v", err)
+	}
+
+	refundTests(t, *refund)
+}
+
+func TestRefundCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds.json", client.pathPrefix),
+		httpmock.NewBytesResponder(201, loadFixture("refund.json")))
+
+	amount := decimal.RequireFromString("41.94")
+	refund := Refund{
+		Note:   "it broke during shipping",
+		Notify: true,
+		RefundLineItems: []RefundLineItem{
+			{LineItemID: 703073504, Quantity: 1, RestockType: RefundRestockTypeReturn, LocationID: 487838322},
+		},
+		Transactions: []Transaction{
+			{ParentID: 801038806, Amount: &amount, Kind: TransactionKindRefund, Gateway: "bogus"},
+		},
+	}
+
+	returnedRefund, err := client.Refund.Create(context.Background(), 450789469, refund)
+	if err != nil {
+		t.Fatalf("Refund.Create returned error: %v", err)
+	}
+
+	refundTests(t, *returnedRefund)
+}
+
+func TestRefundCreateValidationError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors": {"refund_line_items.quantity": ["cannot refund more items than were purchased"]}}`))
+
+	_, err := client.Refund.Create(context.Background(), 450789469, Refund{})
+	responseError, ok := err.(ResponseError)
+	if !ok {
+		t.Fatalf("Refund.Create expected ResponseError, got %#v", err)
+	}
+
+	expected := map[string][]string{
+		"refund_line_items.quantity": {"cannot refund more items than were purchased"},
+	}
+	if !reflect.DeepEqual(responseError.FieldErrors, expected) {
+		t.Errorf("Refund.Create error fields returned %+v, expected %+v", responseError.FieldErrors, expected)
+	}
+}
+
+func TestRefundCalculate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds/calculate.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, loadFixture("refund_calculate.json")), nil
+		})
+
+	refund := Refund{
+		Shipping: &RefundShipping{FullRefund: true},
+		RefundLineItems: []RefundLineItem{
+			{LineItemID: 518995019, Quantity: 1, RestockType: RefundRestockTypeReturn, LocationID: 487838322},
+		},
+	}
+
+	calculated, err := client.Refund.Calculate(context.Background(), 450789469, refund)
+	if err != nil {
+		t.Fatalf("Refund.Calculate returned error: %v", err)
+	}
+
+	expectedSent := map[string]interface{}{
+		"refund": map[string]interface{}{
+			"shipping": map[string]interface{}{"full_refund": true},
+			"refund_line_items": []interface{}{
+				map[string]interface{}{
+					"line_item_id": float64(518995019),
+					"quantity":     float64(1),
+					"restock_type": "return",
+					"location_id":  float64(487838322),
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(sent, expectedSent) {
+		t.Errorf("Refund.Calculate sent %+v, expected %+v", sent, expectedSent)
+	}
+
+	if calculated.Shipping == nil || !calculated.Shipping.MaximumRefundable.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("Refund.Calculate shipping returned %+v", calculated.Shipping)
+	}
+
+	if len(calculated.RefundLineItems) != 1 || !calculated.RefundLineItems[0].TotalTax.Equal(decimal.RequireFromString("3.98")) {
+		t.Errorf("Refund.Calculate refund line items returned %+v", calculated.RefundLineItems)
+	}
+
+	if len(calculated.Transactions) != 1 {
+		t.Fatalf("Refund.Calculate returned %d transactions, expected 1", len(calculated.Transactions))
+	}
+
+	transaction := calculated.Transactions[0]
+	expectedAmount := decimal.RequireFromString("204.65")
+	if transaction.Kind != TransactionKindSuggestedRefund || !transaction.Amount.Equal(expectedAmount) || !transaction.MaximumRefundable.Equal(expectedAmount) {
+		t.Errorf("Refund.Calculate transactions returned %+v", transaction)
+	}
+}
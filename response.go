@@ -0,0 +1,89 @@
+package go_shopify
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Response holds the details of a single API call. Unlike the fields on
+// Client it belongs to one request only, so it is safe to inspect when the
+// client is shared between goroutines.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+
+	// RequestID is the X-Request-Id Shopify assigned, include it when
+	// contacting Shopify support
+	RequestID string
+
+	// APIVersion is the version which actually served the request, it
+	// differs from the requested one once that is no longer supported
+	APIVersion string
+
+	// DeprecatedReason is set when the request used a deprecated endpoint
+	// or field, see X-Shopify-API-Deprecated-Reason
+	DeprecatedReason string
+
+	// Attempts is the number of requests sent, including retries
+	Attempts int
+
+	// RateLimits as reported by the last response received
+	RateLimits RateLimitInfo
+
+	// Pagination parsed from the Link header, nil if it was missing or invalid
+	Pagination *Pagination
+}
+
+// IsDeprecated reports whether Shopify flagged the request as using a
+// deprecated API.
+func (r *Response) IsDeprecated() bool {
+	return r.DeprecatedReason != ""
+}
+
+// populate fills in the response details from an http response.
+func (r *Response) populate(resp *http.Response) {
+	r.StatusCode = resp.StatusCode
+	r.Header = resp.Header
+	r.RequestID = resp.Header.Get("X-Request-Id")
+	r.APIVersion = resp.Header.Get("X-Shopify-API-Version")
+	r.DeprecatedReason = resp.Header.Get("X-Shopify-API-Deprecated-Reason")
+	r.RateLimits = RateLimitInfo{}
+	r.Pagination = nil
+
+	if s := strings.Split(resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(s) == 2 {
+		r.RateLimits.RequestCount, _ = strconv.Atoi(s[0])
+		r.RateLimits.BucketSize, _ = strconv.Atoi(s[1])
+	}
+
+	r.RateLimits.RetryAfterSeconds, _ = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+
+	if resp.Header.Get("Link") != "" {
+		r.Pagination, _ = extractPagination(resp.Header)
+	}
+}
+
+// GetWithResponse is like GetWithContext but also returns the Response, see
+// CreateAndDoWithResponse for when it is set.
+func (c *Client) GetWithResponse(ctx context.Context, path string, resource, options interface{}) (*Response, error) {
+	return c.CreateAndDoWithResponse(ctx, "GET", path, nil, options, resource)
+}
+
+// PostWithResponse is like PostWithContext but also returns the Response, see
+// CreateAndDoWithResponse for when it is set.
+func (c *Client) PostWithResponse(ctx context.Context, path string, data, resource interface{}) (*Response, error) {
+	return c.CreateAndDoWithResponse(ctx, "POST", path, data, nil, resource)
+}
+
+// PutWithResponse is like PutWithContext but also returns the Response, see
+// CreateAndDoWithResponse for when it is set.
+func (c *Client) PutWithResponse(ctx context.Context, path string, data, resource interface{}) (*Response, error) {
+	return c.CreateAndDoWithResponse(ctx, "PUT", path, data, nil, resource)
+}
+
+// DeleteWithResponse is like DeleteWithContext but also returns the Response,
+// see CreateAndDoWithResponse for when it is set.
+func (c *Client) DeleteWithResponse(ctx context.Context, path string) (*Response, error) {
+	return c.CreateAndDoWithResponse(ctx, "DELETE", path, nil, nil, nil)
+}
@@ -0,0 +1,114 @@
+package go_shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestGetWithResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/foos.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL, createResponderWithHeaders(200, `{"foos": []}`, map[string]string{
+		"X-Request-Id":                    "abc-123",
+		"X-Shopify-API-Version":           "2021-10",
+		"X-Shopify-API-Deprecated-Reason": "https://shopify.dev/changelog/deprecated",
+		"X-Shopify-Shop-Api-Call-Limit":   "3/40",
+		"Link":                            fmt.Sprintf(`<%s?page_info=abc&limit=1>; rel="next"`, listURL),
+	}))
+
+	resp, err := client.GetWithResponse(context.Background(), "foos.json", nil, nil)
+	if err != nil {
+		t.Fatalf("Client.GetWithResponse returned error: %v", err)
+	}
+
+	expected := &Response{
+		StatusCode:       200,
+		Header:           resp.Header,
+		RequestID:        "abc-123",
+		APIVersion:       "2021-10",
+		DeprecatedReason: "https://shopify.dev/changelog/deprecated",
+		Attempts:         1,
+		RateLimits:       RateLimitInfo{RequestCount: 3, BucketSize: 40},
+		Pagination:       &Pagination{NextPageOptions: &ListOptions{PageInfo: "abc", Limit: 1}},
+	}
+	if !reflect.DeepEqual(resp, expected) {
+		t.Errorf("Client.GetWithResponse returned %+v, expected %+v", resp, expected)
+	}
+
+	if !resp.IsDeprecated() {
+		t.Errorf("Response.IsDeprecated returned false")
+	}
+}
+
+func TestResponseWithError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/foos/1.json", client.pathPrefix),
+		createResponderWithHeaders(404, `{"errors": "Not Found"}`, map[string]string{
+			"X-Request-Id": "abc-404",
+		}))
+
+	resp, err := client.DeleteWithResponse(context.Background(), "foos/1.json")
+	expected := ResponseError{Status: 404, Message: "Not Found"}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Client.DeleteWithResponse returned error %#v, expected %#v", err, expected)
+	}
+
+	if resp == nil || resp.StatusCode != 404 || resp.RequestID != "abc-404" || resp.IsDeprecated() {
+		t.Errorf("Client.DeleteWithResponse returned %+v", resp)
+	}
+}
+
+func TestResponseWithoutAnswer(t *testing.T) {
+	// a client without retries
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/foos.json", testClient.pathPrefix),
+		httpmock.NewErrorResponder(errors.New("connection refused")))
+
+	resp, err := testClient.GetWithResponse(context.Background(), "foos.json", nil, nil)
+	if err == nil {
+		t.Fatalf("Client.GetWithResponse expected an error")
+	}
+
+	if resp == nil || resp.StatusCode != 0 || resp.Attempts != 1 {
+		t.Errorf("Client.GetWithResponse returned %+v, expected a StatusCode of 0 after 1 attempt", resp)
+	}
+}
+
+func TestPostPutWithResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fooURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/foos.json", client.pathPrefix)
+	httpmock.RegisterResponder("POST", fooURL, createResponderWithHeaders(201, `{"foo": {"id": 1}}`, map[string]string{"X-Request-Id": "post"}))
+	httpmock.RegisterResponder("PUT", fooURL, createResponderWithHeaders(200, `{"foo": {"id": 1}}`, map[string]string{"X-Request-Id": "put"}))
+
+	resource := struct {
+		Foo struct {
+			ID int64 `json:"id"`
+		} `json:"foo"`
+	}{}
+
+	resp, err := client.PostWithResponse(context.Background(), "foos.json", map[string]string{}, &resource)
+	if err != nil || resp.StatusCode != 201 || resp.RequestID != "post" || resource.Foo.ID != 1 {
+		t.Errorf("Client.PostWithResponse returned %+v, %v", resp, err)
+	}
+
+	resp, err = client.PutWithResponse(context.Background(), "foos.json", map[string]string{}, &resource)
+	if err != nil || resp.StatusCode != 200 || resp.RequestID != "put" || resp.Pagination != nil {
+		t.Errorf("Client.PutWithResponse returned %+v, %v", resp, err)
+	}
+}
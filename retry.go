@@ -0,0 +1,140 @@
+package go_shopify
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryPolicy decides whether a failed request is sent again. It is only
+// consulted while the client has retries left, see WithRetry.
+type RetryPolicy interface {
+	// ShouldRetry is called after a failed attempt, starting at 1. Either resp
+	// is the unsuccessful response (its body already consumed) and err the
+	// matching response error, or resp is nil and err is the error returned by
+	// the http client. It returns how long to wait before the next attempt and
+	// whether there should be one at all.
+	ShouldRetry(attempt int, resp *http.Response, err error) (time.Duration, bool)
+}
+
+// BackoffRetryPolicy retries with exponential backoff and jitter. Responses
+// with status 429 are always retried and honor the Retry-After header.
+type BackoffRetryPolicy struct {
+	// RetryStatuses are the response statuses retried besides 429
+	RetryStatuses []int
+
+	// RetryNetworkErrors retries timeouts and connection resets. Note that
+	// the request may have reached Shopify in that case.
+	RetryNetworkErrors bool
+
+	// BaseDelay is the delay after the first attempt, doubled on each
+	// following one. Defaults to 500ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between attempts, including Retry-After.
+	// Defaults to 30s.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used by clients without WithRetryPolicy. It retries
+// 429 and 503 responses.
+var DefaultRetryPolicy RetryPolicy = &BackoffRetryPolicy{
+	RetryStatuses: []int{http.StatusServiceUnavailable},
+}
+
+// ServerErrorRetryPolicy additionally retries 500, 502 and 504 responses as
+// well as timeouts and connection resets.
+var ServerErrorRetryPolicy RetryPolicy = &BackoffRetryPolicy{
+	RetryStatuses: []int{
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+	RetryNetworkErrors: true,
+}
+
+// ShouldRetry implements RetryPolicy
+func (p *BackoffRetryPolicy) ShouldRetry(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if resp == nil {
+		if p.RetryNetworkErrors && isTemporaryNetworkError(err) {
+			return p.backoff(attempt), true
+		}
+		return 0, false
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := parseRetryAfter(resp.Header); ok {
+			return p.capDelay(wait), true
+		}
+		return p.backoff(attempt), true
+	}
+
+	for _, status := range p.RetryStatuses {
+		if resp.StatusCode == status {
+			return p.backoff(attempt), true
+		}
+	}
+
+	return 0, false
+}
+
+// backoff returns the exponential delay for the given attempt with "equal
+// jitter": half of the delay is fixed and the other half random.
+func (p *BackoffRetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+
+	delay := p.capDelay(time.Duration(float64(base) * math.Pow(2, float64(attempt-1))))
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func (p *BackoffRetryPolicy) capDelay(d time.Duration) time.Duration {
+	max := p.MaxDelay
+	if max <= 0 {
+		max = defaultRetryMaxDelay
+	}
+
+	// a huge exponent overflows into a negative duration
+	if d > max || d < 0 {
+		return max
+	}
+	return d
+}
+
+// parseRetryAfter parses the Retry-After header which Shopify sends as a
+// number of seconds, possibly fractional, e.g. "0.5".
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	f, err := strconv.ParseFloat(header.Get("Retry-After"), 64)
+	if err != nil || f < 0 {
+		return 0, false
+	}
+	return time.Duration(f * float64(time.Second)), true
+}
+
+// isTemporaryNetworkError reports whether err is a timeout or a connection
+// reset, both of which may succeed when tried again.
+func isTemporaryNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
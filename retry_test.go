@@ -0,0 +1,180 @@
+package go_shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// timeoutError mimics the net.Error returned by a timed out connection
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestBackoffRetryPolicyShouldRetry(t *testing.T) {
+	policy := &BackoffRetryPolicy{
+		RetryStatuses:      []int{http.StatusBadGateway},
+		RetryNetworkErrors: true,
+		BaseDelay:          100 * time.Millisecond,
+		MaxDelay:           time.Second,
+	}
+
+	retryAfter := func(status int, value string) *http.Response {
+		resp := httpmock.NewStringResponse(status, "")
+		if value != "" {
+			resp.Header.Set("Retry-After", value)
+		}
+		return resp
+	}
+
+	cases := []struct {
+		description string
+		resp        *http.Response
+		err         error
+		retry       bool
+		minWait     time.Duration
+		maxWait     time.Duration
+	}{
+		{"429 with fractional Retry-After", retryAfter(429, "0.5"), nil, true, 500 * time.Millisecond, 500 * time.Millisecond},
+		{"429 with Retry-After above MaxDelay", retryAfter(429, "10"), nil, true, time.Second, time.Second},
+		{"429 without Retry-After", retryAfter(429, ""), nil, true, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"configured status", retryAfter(502, ""), nil, true, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"other status", retryAfter(500, ""), nil, false, 0, 0},
+		{"timeout", nil, &url.Error{Op: "Get", URL: "foo", Err: timeoutError{}}, true, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"connection reset", nil, &url.Error{Op: "Get", URL: "foo", Err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}}, true, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"other network error", nil, errors.New("something something"), false, 0, 0},
+	}
+
+	for _, c := range cases {
+		wait, retry := policy.ShouldRetry(1, c.resp, c.err)
+		if retry != c.retry {
+			t.Errorf("%s: ShouldRetry() retry = %v, expected %v", c.description, retry, c.retry)
+		}
+		if wait < c.minWait || wait > c.maxWait {
+			t.Errorf("%s: ShouldRetry() wait = %s, expected between %s and %s", c.description, wait, c.minWait, c.maxWait)
+		}
+	}
+
+	noNetwork := &BackoffRetryPolicy{}
+	if _, retry := noNetwork.ShouldRetry(1, nil, timeoutError{}); retry {
+		t.Errorf("ShouldRetry() retried a network error without RetryNetworkErrors")
+	}
+}
+
+func TestBackoffRetryPolicyBackoff(t *testing.T) {
+	policy := &BackoffRetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+
+	cases := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second},
+		{100, time.Second},
+	}
+
+	for _, c := range cases {
+		for i := 0; i < 20; i++ {
+			wait := policy.backoff(c.attempt)
+			if wait < c.max/2 || wait > c.max {
+				t.Errorf("backoff(%d) = %s, expected between %s and %s", c.attempt, wait, c.max/2, c.max)
+			}
+		}
+	}
+
+	defaults := &BackoffRetryPolicy{}
+	if wait := defaults.backoff(1); wait < defaultRetryBaseDelay/2 || wait > defaultRetryBaseDelay {
+		t.Errorf("backoff(1) with defaults = %s", wait)
+	}
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	testClient := NewClient(app, "fooshop", "abcd",
+		WithRetry(maxRetries),
+		WithRetryPolicy(&BackoffRetryPolicy{
+			RetryStatuses:      []int{http.StatusBadGateway},
+			RetryNetworkErrors: true,
+			BaseDelay:          time.Millisecond,
+		}))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	var calls int
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			switch calls {
+			case 1:
+				return nil, timeoutError{}
+			case 2:
+				return httpmock.NewStringResponse(http.StatusBadGateway, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{"foo": "bar"}`), nil
+		})
+
+	body := struct {
+		Foo string `json:"foo"`
+	}{}
+	resp, err := testClient.CreateAndDoWithResponse(context.Background(), "GET", "foo/1", nil, nil, &body)
+	if err != nil {
+		t.Fatalf("CreateAndDoWithResponse() returned error: %v", err)
+	}
+
+	if resp.Attempts != 3 || body.Foo != "bar" {
+		t.Errorf("CreateAndDoWithResponse() attempts = %d body = %+v, expected 3 attempts and bar", resp.Attempts, body)
+	}
+
+	// a status the policy does not retry is returned right away
+	calls = 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/foo/2",
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(http.StatusInternalServerError, `{"error": "boom"}`), nil
+		})
+
+	resp, err = testClient.CreateAndDoWithResponse(context.Background(), "GET", "foo/2", nil, nil, nil)
+	expected := ResponseError{Status: http.StatusInternalServerError, Message: "boom"}
+	if fmt.Sprint(err) != fmt.Sprint(expected) || resp.Attempts != 1 {
+		t.Errorf("CreateAndDoWithResponse() err = %v attempts = %d, expected %v after 1 attempt", err, resp.Attempts, expected)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"2.0", 2 * time.Second, true},
+		{"0.5", 500 * time.Millisecond, true},
+		{"", 0, false},
+		{"invalid", 0, false},
+		{"-1", 0, false},
+	}
+
+	for _, c := range cases {
+		header := http.Header{}
+		header.Set("Retry-After", c.value)
+		wait, ok := parseRetryAfter(header)
+		if wait != c.expected || ok != c.ok {
+			t.Errorf("parseRetryAfter(%s): expected %s, %v, actual %s, %v", c.value, c.expected, c.ok, wait, ok)
+		}
+	}
+}
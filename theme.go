@@ -0,0 +1,126 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const themesBasePath = "themes"
+
+// ThemeService is an interface for interfacing with the theme endpoints of
+// the Shopify API.
+// See: https://help.shopify.com/api/reference/theme
+type ThemeService interface {
+	List(context.Context, interface{}) ([]Theme, error)
+	Get(context.Context, int64, interface{}) (*Theme, error)
+	Create(context.Context, Theme) (*Theme, error)
+	Update(context.Context, Theme) (*Theme, error)
+	Delete(context.Context, int64) error
+	Main(context.Context) (*Theme, error)
+}
+
+// ThemeServiceOp handles communication with the theme related methods of the
+// Shopify API.
+type ThemeServiceOp struct {
+	client *Client
+}
+
+// ThemeRole is the role of a theme. Only one theme of a shop is the main,
+// published theme.
+type ThemeRole string
+
+const (
+	ThemeRoleMain        ThemeRole = "main"
+	ThemeRoleUnpublished ThemeRole = "unpublished"
+	ThemeRoleDemo        ThemeRole = "demo"
+	ThemeRoleDevelopment ThemeRole = "development"
+)
+
+// ThemeListOptions are the options for listing themes
+type ThemeListOptions struct {
+	Role   ThemeRole `url:"role,omitempty"`
+	Fields string    `url:"fields,omitempty"`
+}
+
+// Theme represents a Shopify theme
+type Theme struct {
+	ID           int64      `json:"id,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Role         ThemeRole  `json:"role,omitempty"`
+	Previewable  bool       `json:"previewable,omitempty"`
+	Processing   bool       `json:"processing,omitempty"`
+	ThemeStoreID int64      `json:"theme_store_id,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	// Src is the URL of a zip file to create the theme from, it is only used
+	// when creating a theme
+	Src               string `json:"src,omitempty"`
+	AdminGraphqlAPIID string `json:"admin_graphql_api_id,omitempty"`
+}
+
+// ThemeResource represents the result from the themes/X.json endpoint
+type ThemeResource struct {
+	Theme *Theme `json:"theme"`
+}
+
+// ThemesResource represents the result from the themes.json endpoint
+type ThemesResource struct {
+	Themes []Theme `json:"themes"`
+}
+
+// List themes
+func (s *ThemeServiceOp) List(ctx context.Context, options interface{}) ([]Theme, error) {
+	path := fmt.Sprintf("%s.json", themesBasePath)
+	resource := new(ThemesResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Themes, err
+}
+
+// Get individual theme
+func (s *ThemeServiceOp) Get(ctx context.Context, themeID int64, options interface{}) (*Theme, error) {
+	path := fmt.Sprintf("%s/%d.json", themesBasePath, themeID)
+	resource := new(ThemeResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Theme, err
+}
+
+// Create a new theme, usually from the zip file at theme.Src. The theme is
+// processed in the background until Processing is false.
+func (s *ThemeServiceOp) Create(ctx context.Context, theme Theme) (*Theme, error) {
+	path := fmt.Sprintf("%s.json", themesBasePath)
+	wrappedData := ThemeResource{Theme: &theme}
+	resource := new(ThemeResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Theme, err
+}
+
+// Update the name or role of an existing theme, setting the role to main
+// publishes the theme.
+func (s *ThemeServiceOp) Update(ctx context.Context, theme Theme) (*Theme, error) {
+	path := fmt.Sprintf("%s/%d.json", themesBasePath, theme.ID)
+	wrappedData := ThemeResource{Theme: &theme}
+	resource := new(ThemeResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Theme, err
+}
+
+// Delete an existing theme, the main theme can't be deleted
+func (s *ThemeServiceOp) Delete(ctx context.Context, themeID int64) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", themesBasePath, themeID))
+}
+
+// Main returns the published theme of the shop
+func (s *ThemeServiceOp) Main(ctx context.Context) (*Theme, error) {
+	themes, err := s.List(ctx, ThemeListOptions{Role: ThemeRoleMain})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range themes {
+		if themes[i].Role == ThemeRoleMain {
+			return &themes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("shop has no theme with role %s", ThemeRoleMain)
+}
@@ -0,0 +1,387 @@
+package go_shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultThemeDeployConcurrency is the number of assets changed at once
+	// unless ThemeDeployOptions.Concurrency is set
+	defaultThemeDeployConcurrency = 4
+
+	// themeDeployAttempts is the number of attempts for a rate limited change
+	// when the client doesn't retry itself, see WithRetry
+	themeDeployAttempts = 3
+)
+
+// ThemeDiffAction is the change a diff makes to an asset of the destination
+// theme
+type ThemeDiffAction string
+
+const (
+	ThemeDiffActionAdd    ThemeDiffAction = "add"
+	ThemeDiffActionUpdate ThemeDiffAction = "update"
+	ThemeDiffActionDelete ThemeDiffAction = "delete"
+)
+
+// themeDiffActionSigns prefix the changes of a plan, see ThemeDiff.WritePlan
+var themeDiffActionSigns = map[ThemeDiffAction]string{
+	ThemeDiffActionAdd:    "+",
+	ThemeDiffActionUpdate: "~",
+	ThemeDiffActionDelete: "-",
+}
+
+// ThemeDiffChange is the change of a single asset
+type ThemeDiffChange struct {
+	Action ThemeDiffAction
+	Key    string
+}
+
+// ThemeDiff is the plan of changes which make a destination theme match a
+// source theme or a local directory, see DiffThemes and ThemeSync.Diff.
+// Assets are compared by the checksums of AssetService.List.
+type ThemeDiff struct {
+	// Changes are sorted by key
+	Changes   []ThemeDiffChange
+	Unchanged []string
+	Ignored   []string
+
+	client     *Client
+	srcThemeID int64
+	dstThemeID int64
+	// sync is the source of a diff with a local directory
+	sync *ThemeSync
+}
+
+// ThemeDeployOptions are the options for applying a ThemeDiff
+type ThemeDeployOptions struct {
+	// Concurrency is the number of assets changed at once, defaults to 4.
+	// Requests wait for the RateLimiter of the client, or for RateLimiter if
+	// the client has none. Rate limited requests are retried according to the
+	// RetryPolicy of the client, or up to 3 times after Retry-After if the
+	// client doesn't retry.
+	Concurrency int
+
+	// RateLimiter throttles the deploy if the client has no RateLimiter,
+	// defaults to a new one with the bucket of a standard shop
+	RateLimiter *RateLimiter
+
+	// DryRun reports the changes without making them
+	DryRun bool
+
+	// Delete deletes the assets of the destination theme which are missing
+	// from the source, they are skipped otherwise
+	Delete bool
+}
+
+// ThemeDeployFailure is a change which failed to apply
+type ThemeDeployFailure struct {
+	ThemeDiffChange
+	Err error
+}
+
+// ThemeDeployReport lists the asset keys changed by applying a ThemeDiff,
+// sorted
+type ThemeDeployReport struct {
+	DryRun   bool
+	Added    []string
+	Updated  []string
+	Deleted  []string
+	Skipped  []string
+	Failed   []ThemeDeployFailure
+	Duration time.Duration
+}
+
+// DiffThemes compares the assets of two themes, the changes make the theme
+// dstThemeID match the theme srcThemeID. Assets matching one of the ignore
+// patterns are left alone, see ThemeSyncIgnoreFile for their syntax.
+func DiffThemes(ctx context.Context, client *Client, srcThemeID int64, dstThemeID int64, ignore []string) (*ThemeDiff, error) {
+	src, err := client.Asset.ListWithContext(ctx, srcThemeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := client.Asset.ListWithContext(ctx, dstThemeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ThemeDiff{client: client, srcThemeID: srcThemeID, dstThemeID: dstThemeID}
+	srcChecksums := diff.checksums(src, ignore)
+	dstChecksums := diff.checksums(dst, ignore)
+	diff.compare(srcChecksums, dstChecksums)
+	return diff, nil
+}
+
+// Diff compares the local directory with the theme, the changes make the
+// theme match the directory.
+func (s *ThemeSync) Diff(ctx context.Context) (*ThemeDiff, error) {
+	remote, err := s.client.Asset.ListWithContext(ctx, s.themeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := s.localChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ThemeDiff{client: s.client, dstThemeID: s.themeID, sync: s}
+	remoteChecksums := diff.checksums(remote, s.ignore)
+	diff.compare(local, remoteChecksums)
+	return diff, nil
+}
+
+// WritePlan writes the changes of the diff to w, one per line prefixed with
+// + for adds, ~ for updates and - for deletes, followed by a summary line.
+func (d *ThemeDiff) WritePlan(w io.Writer) error {
+	counts := map[ThemeDiffAction]int{}
+	for _, change := range d.Changes {
+		counts[change.Action]++
+		if _, err := fmt.Fprintf(w, "%s %s\n", themeDiffActionSigns[change.Action], change.Key); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "Plan: %d to add, %d to update, %d to delete, %d unchanged, %d ignored.\n",
+		counts[ThemeDiffActionAdd], counts[ThemeDiffActionUpdate], counts[ThemeDiffActionDelete], len(d.Unchanged), len(d.Ignored))
+	return err
+}
+
+// Apply makes the changes of the diff to the destination theme, at most
+// options.Concurrency at once and within the rate limits, see
+// ThemeDeployOptions. It keeps going when a change fails and
+// returns the report along with an error if any change failed, or the error
+// of ctx if it is done before all changes are made.
+func (d *ThemeDiff) Apply(ctx context.Context, options ThemeDeployOptions) (*ThemeDeployReport, error) {
+	start := time.Now()
+	report := &ThemeDeployReport{DryRun: options.DryRun}
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultThemeDeployConcurrency
+	}
+
+	// the requests of the client only wait for a limiter of its own
+	var limiter *RateLimiter
+	if d.client.rateLimiter == nil {
+		limiter = options.RateLimiter
+		if limiter == nil {
+			limiter = NewRateLimiter(DefaultRateLimitBucketSize, DefaultRateLimitLeakRate)
+		}
+	}
+
+	var mu sync.Mutex
+	record := func(change ThemeDiffChange, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.record(change, err)
+	}
+
+	changes := make(chan ThemeDiffChange)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for change := range changes {
+				record(change, d.applyThrottled(ctx, change, limiter))
+			}
+		}()
+	}
+
+	var err error
+dispatch:
+	for _, change := range d.Changes {
+		if change.Action == ThemeDiffActionDelete && !options.Delete {
+			mu.Lock()
+			report.Skipped = append(report.Skipped, change.Key)
+			mu.Unlock()
+			continue
+		}
+		if options.DryRun {
+			record(change, nil)
+			continue
+		}
+
+		select {
+		case changes <- change:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		}
+	}
+	close(changes)
+	wg.Wait()
+
+	report.sort()
+	report.Duration = time.Since(start)
+
+	if err == nil && len(report.Failed) > 0 {
+		// skipped changes were never tried
+		err = fmt.Errorf("theme deploy: %d of %d changes failed, first %s %s: %v",
+			len(report.Failed), len(d.Changes)-len(report.Skipped), report.Failed[0].Action, report.Failed[0].Key, report.Failed[0].Err)
+	}
+	return report, err
+}
+
+// String returns a one line summary of the report
+func (r *ThemeDeployReport) String() string {
+	summary := fmt.Sprintf("%d added, %d updated, %d deleted, %d skipped, %d failed in %s",
+		len(r.Added), len(r.Updated), len(r.Deleted), len(r.Skipped), len(r.Failed), r.Duration.Round(time.Millisecond))
+	if r.DryRun {
+		summary += " (dry run)"
+	}
+	return summary
+}
+
+// applyThrottled makes a single change, waiting for limiter before each
+// request unless it is nil. Rate limited changes are tried again unless the
+// client retries them itself.
+func (d *ThemeDiff) applyThrottled(ctx context.Context, change ThemeDiffChange, limiter *RateLimiter) error {
+	attempts := 1
+	if d.client.retries <= 1 {
+		attempts = themeDeployAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if limiter != nil {
+			for i := 0; i < d.requests(change); i++ {
+				if err := limiter.Wait(ctx); err != nil {
+					return err
+				}
+			}
+		}
+
+		resp, err := d.apply(ctx, change)
+		var rateLimitErr RateLimitError
+		if !errors.As(err, &rateLimitErr) || attempt >= attempts {
+			return err
+		}
+
+		if limiter != nil {
+			limiter.fill()
+		}
+		// RetryAfter is truncated to whole seconds, the response keeps the
+		// fraction Shopify sent
+		wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
+		if resp != nil {
+			wait = time.Duration(resp.RateLimits.RetryAfterSeconds * float64(time.Second))
+		}
+		d.client.log.Debugf("theme deploy: %s %s rate limited, retrying in %s", change.Action, change.Key, wait)
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// requests returns the number of requests apply makes for a change
+func (d *ThemeDiff) requests(change ThemeDiffChange) int {
+	if change.Action != ThemeDiffActionDelete && d.sync == nil {
+		// the asset is read from the source theme first
+		return 2
+	}
+	return 1
+}
+
+// apply makes a single change to the destination theme, returning the
+// Response of the last request made
+func (d *ThemeDiff) apply(ctx context.Context, change ThemeDiffChange) (*Response, error) {
+	assets := &AssetServiceOp{client: d.client}
+	switch change.Action {
+	case ThemeDiffActionDelete:
+		return assets.deleteWithResponse(ctx, d.dstThemeID, change.Key)
+	case ThemeDiffActionAdd, ThemeDiffActionUpdate:
+		if d.sync != nil {
+			return d.sync.upload(ctx, change.Key)
+		}
+		_, resp, err := assets.copyToThemeWithResponse(ctx, d.srcThemeID, change.Key, d.dstThemeID, change.Key)
+		return resp, err
+	}
+	return nil, fmt.Errorf("theme deploy: unknown action %s for %s", change.Action, change.Key)
+}
+
+// checksums returns the checksums of the assets which aren't ignored by key,
+// adding the ignored ones to d.Ignored
+func (d *ThemeDiff) checksums(assets []Asset, ignore []string) map[string]string {
+	checksums := make(map[string]string, len(assets))
+	for _, asset := range assets {
+		if matchThemeIgnorePatterns(ignore, asset.Key) {
+			d.Ignored = append(d.Ignored, asset.Key)
+			continue
+		}
+		checksums[asset.Key] = asset.Checksum
+	}
+	return checksums
+}
+
+// compare fills the changes which make dst match src. Assets without a
+// checksum are always updated.
+func (d *ThemeDiff) compare(src map[string]string, dst map[string]string) {
+	for key, checksum := range src {
+		dstChecksum, ok := dst[key]
+		switch {
+		case !ok:
+			d.Changes = append(d.Changes, ThemeDiffChange{Action: ThemeDiffActionAdd, Key: key})
+		case checksum == "" || checksum != dstChecksum:
+			d.Changes = append(d.Changes, ThemeDiffChange{Action: ThemeDiffActionUpdate, Key: key})
+		default:
+			d.Unchanged = append(d.Unchanged, key)
+		}
+	}
+	for key := range dst {
+		if _, ok := src[key]; !ok {
+			d.Changes = append(d.Changes, ThemeDiffChange{Action: ThemeDiffActionDelete, Key: key})
+		}
+	}
+
+	sort.Slice(d.Changes, func(i, j int) bool {
+		return d.Changes[i].Key < d.Changes[j].Key
+	})
+	sort.Strings(d.Unchanged)
+	d.Ignored = uniqueSortedStrings(d.Ignored)
+}
+
+func (r *ThemeDeployReport) record(change ThemeDiffChange, err error) {
+	if err != nil {
+		r.Failed = append(r.Failed, ThemeDeployFailure{ThemeDiffChange: change, Err: err})
+		return
+	}
+
+	switch change.Action {
+	case ThemeDiffActionAdd:
+		r.Added = append(r.Added, change.Key)
+	case ThemeDiffActionUpdate:
+		r.Updated = append(r.Updated, change.Key)
+	case ThemeDiffActionDelete:
+		r.Deleted = append(r.Deleted, change.Key)
+	}
+}
+
+func (r *ThemeDeployReport) sort() {
+	sort.Strings(r.Added)
+	sort.Strings(r.Updated)
+	sort.Strings(r.Deleted)
+	sort.Strings(r.Skipped)
+	sort.Slice(r.Failed, func(i, j int) bool {
+		return r.Failed[i].Key < r.Failed[j].Key
+	})
+}
+
+// uniqueSortedStrings sorts values and removes duplicates
+func uniqueSortedStrings(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
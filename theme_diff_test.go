@@ -0,0 +1,423 @@
+package go_shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func registerThemeDiffAssets(t *testing.T, themeID int64, assets []Asset) {
+	b, err := json.Marshal(AssetsResource{Assets: assets})
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/%d/assets.json", client.pathPrefix, themeID),
+		httpmock.NewBytesResponder(200, b))
+}
+
+func registerThemeDiffThemes(t *testing.T) {
+	registerThemeDiffAssets(t, 1, []Asset{
+		{Key: "assets/new.css", Checksum: "a1"},
+		{Key: "config/settings_data.json", Checksum: "b1"},
+		{Key: "layout/theme.liquid", Checksum: "c1"},
+		{Key: "templates/index.liquid", Checksum: "d1"},
+	})
+	registerThemeDiffAssets(t, 2, []Asset{
+		{Key: "config/settings_data.json", Checksum: "b2"},
+		{Key: "layout/theme.liquid", Checksum: "c1"},
+		{Key: "snippets/old.liquid", Checksum: "e2"},
+		{Key: "templates/index.liquid", Checksum: "d2"},
+	})
+}
+
+func TestDiffThemes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerThemeDiffThemes(t)
+
+	diff, err := DiffThemes(context.Background(), client, 1, 2, []string{"config/settings_data.json"})
+	if err != nil {
+		t.Fatalf("DiffThemes returned error: %v", err)
+	}
+
+	expectedChanges := []ThemeDiffChange{
+		{Action: ThemeDiffActionAdd, Key: "assets/new.css"},
+		{Action: ThemeDiffActionDelete, Key: "snippets/old.liquid"},
+		{Action: ThemeDiffActionUpdate, Key: "templates/index.liquid"},
+	}
+	if !reflect.DeepEqual(diff.Changes, expectedChanges) {
+		t.Errorf("DiffThemes returned changes %+v, expected %+v", diff.Changes, expectedChanges)
+	}
+
+	expectedUnchanged := []string{"layout/theme.liquid"}
+	if !reflect.DeepEqual(diff.Unchanged, expectedUnchanged) {
+		t.Errorf("DiffThemes returned unchanged %+v, expected %+v", diff.Unchanged, expectedUnchanged)
+	}
+
+	expectedIgnored := []string{"config/settings_data.json"}
+	if !reflect.DeepEqual(diff.Ignored, expectedIgnored) {
+		t.Errorf("DiffThemes returned ignored %+v, expected %+v", diff.Ignored, expectedIgnored)
+	}
+}
+
+func TestThemeDiffWritePlan(t *testing.T) {
+	diff := &ThemeDiff{
+		Changes: []ThemeDiffChange{
+			{Action: ThemeDiffActionAdd, Key: "assets/new.css"},
+			{Action: ThemeDiffActionDelete, Key: "snippets/old.liquid"},
+			{Action: ThemeDiffActionUpdate, Key: "templates/index.liquid"},
+		},
+		Unchanged: []string{"layout/theme.liquid"},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := diff.WritePlan(buf); err != nil {
+		t.Fatalf("ThemeDiff.WritePlan returned error: %v", err)
+	}
+
+	expected := "+ assets/new.css\n" +
+		"- snippets/old.liquid\n" +
+		"~ templates/index.liquid\n" +
+		"Plan: 1 to add, 1 to update, 1 to delete, 1 unchanged, 0 ignored.\n"
+	if buf.String() != expected {
+		t.Errorf("ThemeDiff.WritePlan wrote %q, expected %q", buf.String(), expected)
+	}
+}
+
+func TestThemeDiffApply(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerThemeDiffThemes(t)
+	for _, key := range []string{"assets/new.css", "templates/index.liquid"} {
+		b, _ := json.Marshal(AssetResource{Asset: &Asset{Key: key, Value: "content of " + key}})
+		params := map[string]string{"asset[key]": key, "theme_id": "1"}
+		httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+			params, httpmock.NewBytesResponder(200, b))
+	}
+
+	var mu sync.Mutex
+	var sent []Asset
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/2/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			resource := AssetResource{}
+			_ = json.Unmarshal(b, &resource)
+			mu.Lock()
+			sent = append(sent, *resource.Asset)
+			mu.Unlock()
+			return httpmock.NewBytesResponse(200, b), nil
+		})
+
+	params := map[string]string{"asset[key]": "snippets/old.liquid"}
+	httpmock.RegisterResponderWithQuery("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/2/assets.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, "{}"))
+
+	diff, err := DiffThemes(context.Background(), client, 1, 2, []string{"config/settings_data.json"})
+	if err != nil {
+		t.Fatalf("DiffThemes returned error: %v", err)
+	}
+
+	report, err := diff.Apply(context.Background(), ThemeDeployOptions{Concurrency: 2, Delete: true})
+	if err != nil {
+		t.Fatalf("ThemeDiff.Apply returned error: %v", err)
+	}
+
+	expectedReport := &ThemeDeployReport{
+		Added:    []string{"assets/new.css"},
+		Updated:  []string{"templates/index.liquid"},
+		Deleted:  []string{"snippets/old.liquid"},
+		Duration: report.Duration,
+	}
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("ThemeDiff.Apply returned %+v, expected %+v", report, expectedReport)
+	}
+
+	sort.Slice(sent, func(i, j int) bool { return sent[i].Key < sent[j].Key })
+	expectedSent := []Asset{
+		{Key: "assets/new.css", Value: "content of assets/new.css"},
+		{Key: "templates/index.liquid", Value: "content of templates/index.liquid"},
+	}
+	if !reflect.DeepEqual(sent, expectedSent) {
+		t.Errorf("ThemeDiff.Apply sent %+v, expected %+v", sent, expectedSent)
+	}
+
+	if !strings.HasPrefix(report.String(), "1 added, 1 updated, 1 deleted, 0 skipped, 0 failed in ") {
+		t.Errorf("ThemeDeployReport.String() returned %s", report.String())
+	}
+}
+
+func TestThemeDiffApplyDryRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerThemeDiffThemes(t)
+
+	diff, err := DiffThemes(context.Background(), client, 1, 2, nil)
+	if err != nil {
+		t.Fatalf("DiffThemes returned error: %v", err)
+	}
+
+	// no responders for changes, a dry run must not make any
+	report, err := diff.Apply(context.Background(), ThemeDeployOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ThemeDiff.Apply returned error: %v", err)
+	}
+
+	expectedReport := &ThemeDeployReport{
+		DryRun:   true,
+		Added:    []string{"assets/new.css"},
+		Updated:  []string{"config/settings_data.json", "templates/index.liquid"},
+		Skipped:  []string{"snippets/old.liquid"},
+		Duration: report.Duration,
+	}
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("ThemeDiff.Apply returned %+v, expected %+v", report, expectedReport)
+	}
+
+	if !strings.HasSuffix(report.String(), " (dry run)") {
+		t.Errorf("ThemeDeployReport.String() returned %s", report.String())
+	}
+
+	if calls := httpmock.GetTotalCallCount(); calls != 2 {
+		t.Errorf("ThemeDiff.Apply made %d requests in total, expected only the 2 asset lists", calls)
+	}
+}
+
+func TestThemeDiffApplyFailure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	diff := &ThemeDiff{
+		Changes: []ThemeDiffChange{
+			{Action: ThemeDiffActionDelete, Key: "layout/theme.liquid"},
+			{Action: ThemeDiffActionDelete, Key: "snippets/old.liquid"},
+		},
+		client:     client,
+		srcThemeID: 1,
+		dstThemeID: 2,
+	}
+
+	httpmock.RegisterResponderWithQuery("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/2/assets.json", client.pathPrefix),
+		map[string]string{"asset[key]": "layout/theme.liquid"},
+		httpmock.NewStringResponder(403, `{"errors": "layout/theme.liquid can't be deleted"}`))
+	httpmock.RegisterResponderWithQuery("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/2/assets.json", client.pathPrefix),
+		map[string]string{"asset[key]": "snippets/old.liquid"},
+		httpmock.NewStringResponder(200, "{}"))
+
+	report, err := diff.Apply(context.Background(), ThemeDeployOptions{Delete: true})
+	if err == nil {
+		t.Fatalf("ThemeDiff.Apply expected an error")
+	}
+
+	if !reflect.DeepEqual(report.Deleted, []string{"snippets/old.liquid"}) {
+		t.Errorf("ThemeDiff.Apply deleted %+v, expected snippets/old.liquid", report.Deleted)
+	}
+
+	if len(report.Failed) != 1 || report.Failed[0].Key != "layout/theme.liquid" || report.Failed[0].Action != ThemeDiffActionDelete {
+		t.Fatalf("ThemeDiff.Apply returned failures %+v", report.Failed)
+	}
+	if _, ok := report.Failed[0].Err.(ResponseError); !ok {
+		t.Errorf("ThemeDiff.Apply returned failure %T %v, expected a ResponseError", report.Failed[0].Err, report.Failed[0].Err)
+	}
+}
+
+func TestThemeDiffApplyFailureCountsTriedChanges(t *testing.T) {
+	setup()
+	defer teardown()
+
+	diff := &ThemeDiff{
+		Changes: []ThemeDiffChange{
+			{Action: ThemeDiffActionAdd, Key: "assets/new.css"},
+			{Action: ThemeDiffActionDelete, Key: "snippets/old.liquid"},
+		},
+		client:     client,
+		srcThemeID: 1,
+		dstThemeID: 2,
+	}
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors": "Not Found"}`))
+
+	_, err := diff.Apply(context.Background(), ThemeDeployOptions{})
+	if err == nil {
+		t.Fatalf("ThemeDiff.Apply expected an error")
+	}
+
+	if !strings.Contains(err.Error(), "1 of 1 changes failed") {
+		t.Errorf("ThemeDiff.Apply returned error %q, expected it to leave out the skipped delete", err)
+	}
+}
+
+func TestThemeDiffApplyThrottled(t *testing.T) {
+	// a client without rate limiter and retries
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	diff := &ThemeDiff{client: testClient, dstThemeID: 2}
+	for i := 0; i < 8; i++ {
+		diff.Changes = append(diff.Changes, ThemeDiffChange{Action: ThemeDiffActionDelete, Key: fmt.Sprintf("snippets/%d.liquid", i)})
+	}
+
+	var inFlight, maxInFlight, calls int32
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("=~^https://fooshop.myshopify.com/%s/themes/2/assets.json", testClient.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			if atomic.AddInt32(&calls, 1) == 1 {
+				resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service."}`)
+				resp.Header.Add("Retry-After", "0")
+				return resp, nil
+			}
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	// the rate limited request fills the bucket, leaking fast enough to not slow down the test
+	limiter := NewRateLimiter(DefaultRateLimitBucketSize, 1000)
+	report, err := diff.Apply(context.Background(), ThemeDeployOptions{Concurrency: 3, Delete: true, RateLimiter: limiter})
+	if err != nil {
+		t.Fatalf("ThemeDiff.Apply returned error: %v", err)
+	}
+
+	if len(report.Deleted) != 8 || len(report.Failed) != 0 {
+		t.Errorf("ThemeDiff.Apply returned %s, expected 8 deleted", report)
+	}
+	if calls != 9 {
+		t.Errorf("ThemeDiff.Apply made %d requests, expected 9 with the rate limited one tried again", calls)
+	}
+	if maxInFlight > 3 {
+		t.Errorf("ThemeDiff.Apply had %d requests in flight, expected at most 3", maxInFlight)
+	}
+}
+
+func TestThemeDiffApplyWaitsSubSecondRetryAfter(t *testing.T) {
+	// a client without rate limiter and retries
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion(testApiVersion))
+	httpmock.ActivateNonDefault(testClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	diff := &ThemeDiff{
+		Changes: []ThemeDiffChange{{Action: ThemeDiffActionDelete, Key: "snippets/old.liquid"}},
+		client:  testClient,
+	}
+
+	var calls []time.Time
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("=~^https://fooshop.myshopify.com/%s/themes/0/assets.json", testClient.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, time.Now())
+			if len(calls) == 1 {
+				resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service."}`)
+				resp.Header.Add("Retry-After", "0.2")
+				return resp, nil
+			}
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	limiter := NewRateLimiter(DefaultRateLimitBucketSize, 1000)
+	report, err := diff.Apply(context.Background(), ThemeDeployOptions{Delete: true, RateLimiter: limiter})
+	if err != nil {
+		t.Fatalf("ThemeDiff.Apply returned error: %v", err)
+	}
+
+	if len(report.Deleted) != 1 || len(calls) != 2 {
+		t.Fatalf("ThemeDiff.Apply returned %s after %d requests, expected 1 deleted after 2", report, len(calls))
+	}
+	if waited := calls[1].Sub(calls[0]); waited < 200*time.Millisecond {
+		t.Errorf("ThemeDiff.Apply retried after %s, expected to wait the Retry-After of 200ms", waited)
+	}
+}
+
+func TestThemeDiffApplyCanceled(t *testing.T) {
+	diff := &ThemeDiff{
+		Changes: []ThemeDiffChange{{Action: ThemeDiffActionAdd, Key: "assets/new.css"}},
+		client:  client,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := diff.Apply(ctx, ThemeDeployOptions{})
+	if err == nil {
+		t.Fatalf("ThemeDiff.Apply returned %+v, expected an error", report)
+	}
+}
+
+func TestThemeSyncDiff(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	writeThemeSyncFiles(t, dir, map[string]string{
+		ThemeSyncIgnoreFile:         "config/settings_data.json\n",
+		"templates/index.liquid":    "unchanged",
+		"sections/header.liquid":    "new header",
+		"config/settings_data.json": "{}",
+	})
+
+	registerThemeSyncAssets(t, []Asset{
+		{Key: "templates/index.liquid", Checksum: themeSyncChecksum([]byte("unchanged"))},
+		{Key: "snippets/removed.liquid", Checksum: "0f6c5ba1e7a0e5b4b2a1d1e5f3c2b1a0"},
+		{Key: "config/settings_data.json", Checksum: "1d1c1c2e3d4f5a6b7c8d9e0f1a2b3c4d"},
+	})
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, b), nil
+		})
+
+	sync, err := NewThemeSync(client, 1, dir)
+	if err != nil {
+		t.Fatalf("NewThemeSync returned error: %v", err)
+	}
+
+	diff, err := sync.Diff(context.Background())
+	if err != nil {
+		t.Fatalf("ThemeSync.Diff returned error: %v", err)
+	}
+
+	expectedChanges := []ThemeDiffChange{
+		{Action: ThemeDiffActionAdd, Key: "sections/header.liquid"},
+		{Action: ThemeDiffActionDelete, Key: "snippets/removed.liquid"},
+	}
+	if !reflect.DeepEqual(diff.Changes, expectedChanges) {
+		t.Errorf("ThemeSync.Diff returned changes %+v, expected %+v", diff.Changes, expectedChanges)
+	}
+
+	report, err := diff.Apply(context.Background(), ThemeDeployOptions{})
+	if err != nil {
+		t.Fatalf("ThemeDiff.Apply returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(report.Added, []string{"sections/header.liquid"}) || !reflect.DeepEqual(report.Skipped, []string{"snippets/removed.liquid"}) {
+		t.Errorf("ThemeDiff.Apply returned %+v", report)
+	}
+
+	if sent["asset"]["key"] != "sections/header.liquid" || sent["asset"]["value"] != "new header" {
+		t.Errorf("ThemeDiff.Apply sent %+v", sent["asset"])
+	}
+}
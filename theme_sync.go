@@ -0,0 +1,331 @@
+package go_shopify
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ThemeSyncIgnoreFile is the file in the directory of a ThemeSync listing the
+// asset keys to leave alone, one pattern per line. Lines starting with # are
+// comments. A pattern without a slash matches the base name of a key, a
+// pattern ending with a slash matches everything in a directory, any other
+// pattern matches the whole key, see path.Match.
+const ThemeSyncIgnoreFile = ".shopifyignore"
+
+// themeAssetDirs are the directories of a theme, files outside of them are
+// never synced
+var themeAssetDirs = []string{"assets", "blocks", "config", "layout", "locales", "sections", "snippets", "templates"}
+
+// ThemeSync mirrors the assets of a theme to a local directory and pushes
+// local changes back to the theme.
+type ThemeSync struct {
+	client  *Client
+	themeID int64
+	dir     string
+	ignore  []string
+}
+
+// ThemeSyncOptions are the options for pulling or pushing a theme
+type ThemeSyncOptions struct {
+	// Delete removes the assets which only exist at the destination, local
+	// files when pulling and theme assets when pushing
+	Delete bool
+}
+
+// ThemeSyncResult lists the asset keys touched by a pull or a push, sorted
+type ThemeSyncResult struct {
+	Updated   []string
+	Deleted   []string
+	Unchanged []string
+	Ignored   []string
+}
+
+// NewThemeSync returns a ThemeSync between the theme and dir, reading the
+// ignore file of dir if there is one.
+func NewThemeSync(client *Client, themeID int64, dir string) (*ThemeSync, error) {
+	ignore, err := readThemeSyncIgnoreFile(filepath.Join(dir, ThemeSyncIgnoreFile))
+	if err != nil {
+		return nil, err
+	}
+	return &ThemeSync{client: client, themeID: themeID, dir: dir, ignore: ignore}, nil
+}
+
+// Pull downloads the assets of the theme whose checksum differs from the
+// local file. It fails on asset keys outside of the theme directories.
+func (s *ThemeSync) Pull(ctx context.Context, options ThemeSyncOptions) (*ThemeSyncResult, error) {
+	remote, err := s.client.Asset.ListWithContext(ctx, s.themeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := s.localChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ThemeSyncResult)
+	defer result.sort()
+
+	remoteKeys := make(map[string]bool, len(remote))
+	for _, asset := range remote {
+		remoteKeys[asset.Key] = true
+		if s.Ignored(asset.Key) {
+			result.Ignored = append(result.Ignored, asset.Key)
+			continue
+		}
+		if checksum, ok := local[asset.Key]; ok && asset.Checksum != "" && checksum == asset.Checksum {
+			result.Unchanged = append(result.Unchanged, asset.Key)
+			continue
+		}
+
+		p, err := s.assetFilePath(asset.Key)
+		if err != nil {
+			return result, err
+		}
+		full, err := s.client.Asset.GetWithContext(ctx, s.themeID, asset.Key)
+		if err != nil {
+			return result, err
+		}
+		content, err := full.Content()
+		if err != nil {
+			return result, err
+		}
+		if err := writeThemeSyncFile(p, content); err != nil {
+			return result, err
+		}
+		result.Updated = append(result.Updated, asset.Key)
+	}
+
+	if options.Delete {
+		for key := range local {
+			if remoteKeys[key] {
+				continue
+			}
+			if err := os.Remove(s.localPath(key)); err != nil {
+				return result, err
+			}
+			result.Deleted = append(result.Deleted, key)
+		}
+	}
+
+	return result, nil
+}
+
+// Push uploads the local files whose checksum differs from the asset of the
+// theme.
+func (s *ThemeSync) Push(ctx context.Context, options ThemeSyncOptions) (*ThemeSyncResult, error) {
+	remote, err := s.client.Asset.ListWithContext(ctx, s.themeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := s.localChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ThemeSyncResult)
+	defer result.sort()
+
+	remoteChecksums := make(map[string]string, len(remote))
+	for _, asset := range remote {
+		remoteChecksums[asset.Key] = asset.Checksum
+	}
+
+	for key, checksum := range local {
+		if remoteChecksum, ok := remoteChecksums[key]; ok && remoteChecksum == checksum {
+			result.Unchanged = append(result.Unchanged, key)
+			continue
+		}
+
+		if _, err := s.upload(ctx, key); err != nil {
+			return result, err
+		}
+		result.Updated = append(result.Updated, key)
+	}
+
+	for _, asset := range remote {
+		if _, ok := local[asset.Key]; ok {
+			continue
+		}
+		if s.Ignored(asset.Key) {
+			result.Ignored = append(result.Ignored, asset.Key)
+			continue
+		}
+		if !options.Delete {
+			continue
+		}
+		if err := s.client.Asset.DeleteWithContext(ctx, s.themeID, asset.Key); err != nil {
+			return result, err
+		}
+		result.Deleted = append(result.Deleted, asset.Key)
+	}
+
+	return result, nil
+}
+
+// Ignored reports whether an asset key matches a pattern of the ignore file
+func (s *ThemeSync) Ignored(key string) bool {
+	return matchThemeIgnorePatterns(s.ignore, key)
+}
+
+// localChecksums returns the md5 checksums of the local files which are not
+// ignored, by asset key
+func (s *ThemeSync) localChecksums() (map[string]string, error) {
+	checksums := make(map[string]string)
+	for _, assetDir := range themeAssetDirs {
+		root := filepath.Join(s.dir, assetDir)
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && p == root {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(s.dir, p)
+			if err != nil {
+				return err
+			}
+			key := filepath.ToSlash(rel)
+			if s.Ignored(key) {
+				return nil
+			}
+
+			content, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			checksums[key] = themeSyncChecksum(content)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return checksums, nil
+}
+
+func (s *ThemeSync) localPath(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+// upload sends the local file of key, returning the Response of Shopify if
+// it was reached
+func (s *ThemeSync) upload(ctx context.Context, key string) (*Response, error) {
+	f, err := os.Open(s.localPath(key))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	assets := &AssetServiceOp{client: s.client}
+	_, resp, err := assets.uploadWithResponse(ctx, s.themeID, key, f)
+	return resp, err
+}
+
+// assetFilePath returns the local path of a remote asset key. Keys outside of
+// the themeAssetDirs, e.g. "../.bashrc" or "assets/../../.bashrc", are
+// rejected so a pull never writes outside of the directory.
+func (s *ThemeSync) assetFilePath(key string) (string, error) {
+	segments := strings.SplitN(key, "/", 2)
+	if len(segments) != 2 || !isThemeAssetDir(segments[0]) {
+		return "", fmt.Errorf("theme sync: asset key %s is not in a theme directory", key)
+	}
+
+	p := s.localPath(key)
+	rel, err := filepath.Rel(filepath.Join(s.dir, segments[0]), p)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("theme sync: asset key %s is outside of %s", key, segments[0])
+	}
+	return p, nil
+}
+
+func writeThemeSyncFile(p string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, content, 0644)
+}
+
+// isThemeAssetDir reports whether dir is one of the themeAssetDirs
+func isThemeAssetDir(dir string) bool {
+	for _, assetDir := range themeAssetDirs {
+		if dir == assetDir {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ThemeSyncResult) sort() {
+	sort.Strings(r.Updated)
+	sort.Strings(r.Deleted)
+	sort.Strings(r.Unchanged)
+	sort.Strings(r.Ignored)
+}
+
+// matchThemeIgnorePatterns reports whether an asset key matches one of the
+// patterns, see ThemeSyncIgnoreFile
+func matchThemeIgnorePatterns(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") {
+			if strings.HasPrefix(key, pattern) {
+				return true
+			}
+			continue
+		}
+
+		name := key
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(key)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readThemeSyncIgnoreFile returns the patterns of an ignore file, a missing
+// file has no patterns
+func readThemeSyncIgnoreFile(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, strings.TrimPrefix(line, "/"))
+	}
+	return patterns, scanner.Err()
+}
+
+// themeSyncChecksum returns the checksum of content the way Shopify computes
+// the checksum of an asset
+func themeSyncChecksum(content []byte) string {
+	sum := md5.Sum(content)
+	return hex.EncodeToString(sum[:])
+}
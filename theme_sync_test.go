@@ -0,0 +1,307 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+var themeSyncPNG = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff}
+
+func writeThemeSyncFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func registerThemeSyncAssets(t *testing.T, assets []Asset) {
+	b, err := json.Marshal(AssetsResource{Assets: assets})
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, b))
+}
+
+func registerThemeSyncAsset(t *testing.T, asset Asset) {
+	b, err := json.Marshal(AssetResource{Asset: &asset})
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := map[string]string{"asset[key]": asset.Key, "theme_id": "1"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		params, httpmock.NewBytesResponder(200, b))
+}
+
+func TestThemeSyncPull(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	writeThemeSyncFiles(t, dir, map[string]string{
+		ThemeSyncIgnoreFile:      "# generated by the theme editor\nconfig/settings_data.json\n\n*.map\n",
+		"templates/index.liquid": "unchanged",
+		"snippets/old.liquid":    "removed from the theme",
+	})
+
+	registerThemeSyncAssets(t, []Asset{
+		{Key: "templates/index.liquid", Checksum: themeSyncChecksum([]byte("unchanged"))},
+		{Key: "layout/theme.liquid", Checksum: "0f6c5ba1e7a0e5b4b2a1d1e5f3c2b1a0"},
+		{Key: "assets/logo.png", Checksum: themeSyncChecksum(themeSyncPNG)},
+		{Key: "assets/app.js.map", Checksum: "6d1c1c2e3d4f5a6b7c8d9e0f1a2b3c4d"},
+		{Key: "config/settings_data.json", Checksum: "1d1c1c2e3d4f5a6b7c8d9e0f1a2b3c4d"},
+	})
+	registerThemeSyncAsset(t, Asset{Key: "layout/theme.liquid", Value: "{{ content_for_layout }}"})
+	registerThemeSyncAsset(t, Asset{Key: "assets/logo.png", Attachment: base64.StdEncoding.EncodeToString(themeSyncPNG)})
+
+	sync, err := NewThemeSync(client, 1, dir)
+	if err != nil {
+		t.Fatalf("NewThemeSync returned error: %v", err)
+	}
+
+	result, err := sync.Pull(context.Background(), ThemeSyncOptions{Delete: true})
+	if err != nil {
+		t.Fatalf("ThemeSync.Pull returned error: %v", err)
+	}
+
+	expected := &ThemeSyncResult{
+		Updated:   []string{"assets/logo.png", "layout/theme.liquid"},
+		Deleted:   []string{"snippets/old.liquid"},
+		Unchanged: []string{"templates/index.liquid"},
+		Ignored:   []string{"assets/app.js.map", "config/settings_data.json"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ThemeSync.Pull returned %+v, expected %+v", result, expected)
+	}
+
+	expectedFiles := map[string][]byte{
+		"layout/theme.liquid":    []byte("{{ content_for_layout }}"),
+		"assets/logo.png":        themeSyncPNG,
+		"templates/index.liquid": []byte("unchanged"),
+	}
+	for name, expectedContent := range expectedFiles {
+		content, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("ThemeSync.Pull did not write %s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(content, expectedContent) {
+			t.Errorf("ThemeSync.Pull wrote %q to %s, expected %q", content, name, expectedContent)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "snippets", "old.liquid")); !os.IsNotExist(err) {
+		t.Errorf("ThemeSync.Pull did not delete snippets/old.liquid: %v", err)
+	}
+}
+
+func TestThemeSyncPullRejectsKeysOutsideTheme(t *testing.T) {
+	keys := []string{
+		"../evil.liquid",
+		"assets/../../evil.liquid",
+		"assets/../evil.liquid",
+		"evil.liquid",
+		"assets/",
+	}
+
+	for _, key := range keys {
+		setup()
+
+		root := t.TempDir()
+		dir := filepath.Join(root, "theme")
+		registerThemeSyncAssets(t, []Asset{{Key: key, Checksum: themeSyncChecksum([]byte("evil"))}})
+		registerThemeSyncAsset(t, Asset{Key: key, Value: "evil"})
+
+		sync, err := NewThemeSync(client, 1, dir)
+		if err != nil {
+			t.Fatalf("NewThemeSync returned error: %v", err)
+		}
+
+		if _, err := sync.Pull(context.Background(), ThemeSyncOptions{}); err == nil {
+			t.Errorf("ThemeSync.Pull of %s expected an error", key)
+		}
+
+		for _, name := range []string{filepath.Join(root, "evil.liquid"), filepath.Join(dir, "evil.liquid")} {
+			if _, err := os.Stat(name); !os.IsNotExist(err) {
+				t.Errorf("ThemeSync.Pull of %s wrote %s", key, name)
+			}
+		}
+
+		teardown()
+	}
+}
+
+func TestThemeSyncPush(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	writeThemeSyncFiles(t, dir, map[string]string{
+		ThemeSyncIgnoreFile:         "config/settings_data.json\n",
+		"README.md":                 "not part of the theme",
+		"templates/index.liquid":    "unchanged",
+		"sections/header.liquid":    "new header",
+		"assets/logo.png":           string(themeSyncPNG),
+		"config/settings_data.json": "{}",
+	})
+
+	registerThemeSyncAssets(t, []Asset{
+		{Key: "templates/index.liquid", Checksum: themeSyncChecksum([]byte("unchanged"))},
+		{Key: "sections/header.liquid", Checksum: themeSyncChecksum([]byte("old header"))},
+		{Key: "snippets/removed.liquid", Checksum: "0f6c5ba1e7a0e5b4b2a1d1e5f3c2b1a0"},
+		{Key: "config/settings_data.json", Checksum: "1d1c1c2e3d4f5a6b7c8d9e0f1a2b3c4d"},
+	})
+
+	sent := map[string]Asset{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			resource := AssetResource{}
+			_ = json.Unmarshal(b, &resource)
+			sent[resource.Asset.Key] = *resource.Asset
+			return httpmock.NewBytesResponse(200, b), nil
+		})
+
+	params := map[string]string{"asset[key]": "snippets/removed.liquid"}
+	httpmock.RegisterResponderWithQuery("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, "{}"))
+
+	sync, err := NewThemeSync(client, 1, dir)
+	if err != nil {
+		t.Fatalf("NewThemeSync returned error: %v", err)
+	}
+
+	result, err := sync.Push(context.Background(), ThemeSyncOptions{Delete: true})
+	if err != nil {
+		t.Fatalf("ThemeSync.Push returned error: %v", err)
+	}
+
+	expected := &ThemeSyncResult{
+		Updated:   []string{"assets/logo.png", "sections/header.liquid"},
+		Deleted:   []string{"snippets/removed.liquid"},
+		Unchanged: []string{"templates/index.liquid"},
+		Ignored:   []string{"config/settings_data.json"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ThemeSync.Push returned %+v, expected %+v", result, expected)
+	}
+
+	expectedSent := map[string]Asset{
+		"sections/header.liquid": {Key: "sections/header.liquid", Value: "new header"},
+		"assets/logo.png":        {Key: "assets/logo.png", Attachment: base64.StdEncoding.EncodeToString(themeSyncPNG)},
+	}
+	if !reflect.DeepEqual(sent, expectedSent) {
+		t.Errorf("ThemeSync.Push sent %+v, expected %+v", sent, expectedSent)
+	}
+}
+
+func TestThemeSyncPushEmptyFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	writeThemeSyncFiles(t, dir, map[string]string{
+		"snippets/empty.liquid": "",
+	})
+
+	registerThemeSyncAssets(t, []Asset{
+		{Key: "snippets/empty.liquid", Checksum: themeSyncChecksum([]byte("old content"))},
+	})
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1/assets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, b), nil
+		})
+
+	sync, err := NewThemeSync(client, 1, dir)
+	if err != nil {
+		t.Fatalf("NewThemeSync returned error: %v", err)
+	}
+
+	result, err := sync.Push(context.Background(), ThemeSyncOptions{})
+	if err != nil {
+		t.Fatalf("ThemeSync.Push returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result.Updated, []string{"snippets/empty.liquid"}) {
+		t.Errorf("ThemeSync.Push updated %+v, expected snippets/empty.liquid", result.Updated)
+	}
+
+	expectedSent := map[string]interface{}{"key": "snippets/empty.liquid", "value": ""}
+	for field, expected := range expectedSent {
+		if actual, ok := sent["asset"][field]; !ok || actual != expected {
+			t.Errorf("ThemeSync.Push sent %s %v, expected %q", field, actual, expected)
+		}
+	}
+}
+
+func TestThemeSyncPushWithoutDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	writeThemeSyncFiles(t, dir, map[string]string{
+		"templates/index.liquid": "unchanged",
+	})
+
+	registerThemeSyncAssets(t, []Asset{
+		{Key: "templates/index.liquid", Checksum: themeSyncChecksum([]byte("unchanged"))},
+		{Key: "snippets/remote.liquid", Checksum: "0f6c5ba1e7a0e5b4b2a1d1e5f3c2b1a0"},
+	})
+
+	sync, err := NewThemeSync(client, 1, dir)
+	if err != nil {
+		t.Fatalf("NewThemeSync returned error: %v", err)
+	}
+
+	result, err := sync.Push(context.Background(), ThemeSyncOptions{})
+	if err != nil {
+		t.Fatalf("ThemeSync.Push returned error: %v", err)
+	}
+
+	expected := &ThemeSyncResult{Unchanged: []string{"templates/index.liquid"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ThemeSync.Push returned %+v, expected %+v", result, expected)
+	}
+}
+
+func TestThemeSyncIgnored(t *testing.T) {
+	sync := &ThemeSync{ignore: []string{"config/settings_data.json", "*.map", "assets/vendor/", "locales/*.schema.json"}}
+
+	cases := []struct {
+		key      string
+		expected bool
+	}{
+		{"config/settings_data.json", true},
+		{"config/settings_schema.json", false},
+		{"assets/app.js.map", true},
+		{"assets/vendor/jquery.js", true},
+		{"assets/vendor.js", false},
+		{"locales/en.default.schema.json", true},
+		{"locales/en.default.json", false},
+	}
+
+	for _, c := range cases {
+		if actual := sync.Ignored(c.key); actual != c.expected {
+			t.Errorf("ThemeSync.Ignored(%s) returned %v, expected %v", c.key, actual, c.expected)
+		}
+	}
+}
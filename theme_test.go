@@ -0,0 +1,216 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func themeTests(t *testing.T, theme Theme) {
+	// Check that ID is assigned to the returned theme
+	var expectedInt int64 = 828155753
+	if theme.ID != expectedInt {
+		t.Errorf("Theme.ID returned %+v, expected %+v", theme.ID, expectedInt)
+	}
+
+	cases := []struct {
+		field    string
+		actual   string
+		expected string
+	}{
+		{"Name", theme.Name, "Comfort"},
+		{"Role", string(theme.Role), string(ThemeRoleMain)},
+		{"AdminGraphqlAPIID", theme.AdminGraphqlAPIID, "gid://shopify/Theme/828155753"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("Theme.%s returned %+v, expected %+v", c.field, c.actual, c.expected)
+		}
+	}
+
+	if !theme.Previewable || theme.Processing {
+		t.Errorf("Theme returned Previewable %v and Processing %v, expected true and false", theme.Previewable, theme.Processing)
+	}
+
+	expectedTime := time.Date(2022, time.April, 5, 16, 58, 41, 0, time.UTC)
+	if theme.CreatedAt == nil || !theme.CreatedAt.Equal(expectedTime) {
+		t.Errorf("Theme.CreatedAt returned %+v, expected %+v", theme.CreatedAt, expectedTime)
+	}
+}
+
+func TestThemeList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("themes.json")))
+
+	themes, err := client.Theme.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Theme.List returned error: %v", err)
+	}
+
+	if len(themes) != 3 {
+		t.Fatalf("Theme.List returned %d themes, expected 3", len(themes))
+	}
+
+	themeTests(t, themes[1])
+
+	if themes[0].Role != ThemeRoleDemo || themes[0].ThemeStoreID != 1234 {
+		t.Errorf("Theme.List returned %+v as first theme", themes[0])
+	}
+	if themes[2].Role != ThemeRoleUnpublished || themes[2].Name != "Sandbox" {
+		t.Errorf("Theme.List returned %+v as third theme", themes[2])
+	}
+}
+
+func TestThemeListFilter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"role": "unpublished"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"themes": [{"id":752253240,"role":"unpublished"}]}`))
+
+	themes, err := client.Theme.List(context.Background(), ThemeListOptions{Role: ThemeRoleUnpublished})
+	if err != nil {
+		t.Errorf("Theme.List returned error: %v", err)
+	}
+
+	expected := []Theme{{ID: 752253240, Role: ThemeRoleUnpublished}}
+	if !reflect.DeepEqual(themes, expected) {
+		t.Errorf("Theme.List returned %+v, expected %+v", themes, expected)
+	}
+}
+
+func TestThemeGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/828155753.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("theme.json")))
+
+	theme, err := client.Theme.Get(context.Background(), 828155753, nil)
+	if err != nil {
+		t.Fatalf("Theme.Get returned error: %v", err)
+	}
+
+	themeTests(t, *theme)
+}
+
+func TestThemeCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(201, loadFixture("theme.json")), nil
+		})
+
+	theme := Theme{
+		Name: "Comfort",
+		Src:  "https://example.com/themes/comfort.zip",
+		Role: ThemeRoleUnpublished,
+	}
+
+	returnedTheme, err := client.Theme.Create(context.Background(), theme)
+	if err != nil {
+		t.Fatalf("Theme.Create returned error: %v", err)
+	}
+
+	themeTests(t, *returnedTheme)
+
+	expectedSent := map[string]interface{}{
+		"name": "Comfort",
+		"src":  "https://example.com/themes/comfort.zip",
+		"role": "unpublished",
+	}
+	if !reflect.DeepEqual(sent["theme"], expectedSent) {
+		t.Errorf("Theme.Create sent %+v, expected %+v", sent["theme"], expectedSent)
+	}
+}
+
+func TestThemeUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/828155753.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(200, loadFixture("theme.json")), nil
+		})
+
+	theme := Theme{
+		ID:   828155753,
+		Role: ThemeRoleMain,
+	}
+
+	returnedTheme, err := client.Theme.Update(context.Background(), theme)
+	if err != nil {
+		t.Fatalf("Theme.Update returned error: %v", err)
+	}
+
+	themeTests(t, *returnedTheme)
+
+	if sent["theme"]["role"] != "main" {
+		t.Errorf("Theme.Update sent %+v", sent)
+	}
+}
+
+func TestThemeDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Theme.Delete(context.Background(), 1)
+	if err != nil {
+		t.Errorf("Theme.Delete returned error: %v", err)
+	}
+}
+
+func TestThemeMain(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"role": "main"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/themes.json", client.pathPrefix),
+		params,
+		httpmock.NewBytesResponder(200, loadFixture("themes.json")))
+
+	theme, err := client.Theme.Main(context.Background())
+	if err != nil {
+		t.Fatalf("Theme.Main returned error: %v", err)
+	}
+
+	themeTests(t, *theme)
+}
+
+func TestThemeMainNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/themes.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"themes": [{"id":752253240,"role":"unpublished"}]}`))
+
+	theme, err := client.Theme.Main(context.Background())
+	if err == nil {
+		t.Errorf("Theme.Main returned %+v, expected an error", theme)
+	}
+}
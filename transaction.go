@@ -0,0 +1,130 @@
+package go_shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const transactionsBasePath = "transactions"
+
+// TransactionService is an interface for interfacing with the transaction
+// endpoints of the Shopify API.
+// See: https://help.shopify.com/api/reference/transaction
+type TransactionService interface {
+	List(context.Context, int64, interface{}) ([]Transaction, error)
+	Count(context.Context, int64, interface{}) (int, error)
+	Get(context.Context, int64, int64, interface{}) (*Transaction, error)
+	Create(context.Context, int64, Transaction) (*Transaction, error)
+}
+
+// TransactionServiceOp handles communication with the transaction related
+// methods of the Shopify API.
+type TransactionServiceOp struct {
+	client *Client
+}
+
+// TransactionKind is the kind of a transaction
+type TransactionKind string
+
+const (
+	TransactionKindAuthorization TransactionKind = "authorization"
+	TransactionKindCapture       TransactionKind = "capture"
+	TransactionKindSale          TransactionKind = "sale"
+	TransactionKindVoid          TransactionKind = "void"
+	TransactionKindRefund        TransactionKind = "refund"
+	// only returned by RefundService.Calculate
+	TransactionKindSuggestedRefund TransactionKind = "suggested_refund"
+)
+
+// TransactionStatus is the status of a transaction
+type TransactionStatus string
+
+const (
+	TransactionStatusPending TransactionStatus = "pending"
+	TransactionStatusFailure TransactionStatus = "failure"
+	TransactionStatusSuccess TransactionStatus = "success"
+	TransactionStatusError   TransactionStatus = "error"
+)
+
+// Transaction represents a Shopify transaction. Captures, voids and refunds
+// reference the transaction they apply to through ParentID.
+type Transaction struct {
+	ID                int64             `json:"id,omitempty"`
+	OrderID           int64             `json:"order_id,omitempty"`
+	ParentID          int64             `json:"parent_id,omitempty"`
+	Kind              TransactionKind   `json:"kind,omitempty"`
+	Status            TransactionStatus `json:"status,omitempty"`
+	Amount            *decimal.Decimal  `json:"amount,omitempty"`
+	Currency          string            `json:"currency,omitempty"`
+	Gateway           string            `json:"gateway,omitempty"`
+	Message           string            `json:"message,omitempty"`
+	ErrorCode         string            `json:"error_code,omitempty"`
+	Authorization     string            `json:"authorization,omitempty"`
+	Test              bool              `json:"test,omitempty"`
+	SourceName        string            `json:"source_name,omitempty"`
+	LocationID        int64             `json:"location_id,omitempty"`
+	UserID            int64             `json:"user_id,omitempty"`
+	DeviceID          int64             `json:"device_id,omitempty"`
+	CreatedAt         *time.Time        `json:"created_at,omitempty"`
+	ProcessedAt       *time.Time        `json:"processed_at,omitempty"`
+	Receipt           interface{}       `json:"receipt,omitempty"`
+	PaymentDetails    *PaymentDetails   `json:"payment_details,omitempty"`
+	AdminGraphqlAPIID string            `json:"admin_graphql_api_id,omitempty"`
+	// MaximumRefundable is only set on suggested refunds
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable,omitempty"`
+}
+
+// PaymentDetails represents the payment method of a transaction
+type PaymentDetails struct {
+	AVSResultCode     string `json:"avs_result_code,omitempty"`
+	CreditCardBin     string `json:"credit_card_bin,omitempty"`
+	CVVResultCode     string `json:"cvv_result_code,omitempty"`
+	CreditCardNumber  string `json:"credit_card_number,omitempty"`
+	CreditCardCompany string `json:"credit_card_company,omitempty"`
+}
+
+// TransactionResource represents the result from the orders/X/transactions/Y.json endpoint
+type TransactionResource struct {
+	Transaction *Transaction `json:"transaction"`
+}
+
+// TransactionsResource represents the result from the orders/X/transactions.json endpoint
+type TransactionsResource struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+// List transactions of an order
+func (s *TransactionServiceOp) List(ctx context.Context, orderID int64, options interface{}) ([]Transaction, error) {
+	path := fmt.Sprintf("%s/%d/%s.json", ordersBasePath, orderID, transactionsBasePath)
+	resource := new(TransactionsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Transactions, err
+}
+
+// Count transactions of an order
+func (s *TransactionServiceOp) Count(ctx context.Context, orderID int64, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%d/%s/count.json", ordersBasePath, orderID, transactionsBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual transaction of an order
+func (s *TransactionServiceOp) Get(ctx context.Context, orderID int64, transactionID int64, options interface{}) (*Transaction, error) {
+	path := fmt.Sprintf("%s/%d/%s/%d.json", ordersBasePath, orderID, transactionsBasePath, transactionID)
+	resource := new(TransactionResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Transaction, err
+}
+
+// Create a new transaction for an order, e.g. a capture, void or refund of
+// the transaction given by ParentID. Failed validations are returned as a
+// ResponseError with FieldErrors.
+func (s *TransactionServiceOp) Create(ctx context.Context, orderID int64, transaction Transaction) (*Transaction, error) {
+	path := fmt.Sprintf("%s/%d/%s.json", ordersBasePath, orderID, transactionsBasePath)
+	wrappedData := TransactionResource{Transaction: &transaction}
+	resource := new(TransactionResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Transaction, err
+}
@@ -0,0 +1,177 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func transactionTests(t *testing.T, transaction Transaction) {
+	// Check that the ID is assigned to the returned transaction
+	var expectedInt int64 = 389404469
+	if transaction.ID != expectedInt {
+		t.Errorf("Transaction.ID returned %+v, expected %+v", transaction.ID, expectedInt)
+	}
+
+	expectedInt = 450789469
+	if transaction.OrderID != expectedInt {
+		t.Errorf("Transaction.OrderID returned %+v, expected %+v", transaction.OrderID, expectedInt)
+	}
+
+	if transaction.Kind != TransactionKindAuthorization || transaction.Status != TransactionStatusSuccess {
+		t.Errorf("Transaction.Kind/Status returned %s/%s", transaction.Kind, transaction.Status)
+	}
+
+	expectedAmount := decimal.RequireFromString("409.94")
+	if transaction.Amount == nil || !transaction.Amount.Equal(expectedAmount) {
+		t.Errorf("Transaction.Amount returned %v, expected %v", transaction.Amount, expectedAmount)
+	}
+
+	expectedTime := time.Date(2005, time.August, 1, 15, 57, 11, 0, time.UTC)
+	if transaction.ProcessedAt == nil || !transaction.ProcessedAt.Equal(expectedTime) {
+		t.Errorf("Transaction.ProcessedAt returned %+v, expected %+v", transaction.ProcessedAt, expectedTime)
+	}
+
+	expectedPaymentDetails := &PaymentDetails{
+		CreditCardNumber:  "•••• •••• •••• 4242",
+		CreditCardCompany: "Visa",
+	}
+	if !reflect.DeepEqual(transaction.PaymentDetails, expectedPaymentDetails) {
+		t.Errorf("Transaction.PaymentDetails returned %+v, expected %+v", transaction.PaymentDetails, expectedPaymentDetails)
+	}
+
+	expectedReceipt := map[string]interface{}{"testcase": true, "authorization": "123456"}
+	if !reflect.DeepEqual(transaction.Receipt, expectedReceipt) {
+		t.Errorf("Transaction.Receipt returned %+v, expected %+v", transaction.Receipt, expectedReceipt)
+	}
+}
+
+func TestTransactionList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/transactions.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("transactions.json")))
+
+	transactions, err := client.Transaction.List(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Errorf("Transaction.List returned error: %v", err)
+	}
+
+	authorized := decimal.RequireFromString("409.94")
+	captured := decimal.RequireFromString("250.94")
+	expected := []Transaction{
+		{ID: 389404469, OrderID: 450789469, Kind: TransactionKindAuthorization, Status: TransactionStatusSuccess, Amount: &authorized},
+		{ID: 801038806, OrderID: 450789469, ParentID: 389404469, Kind: TransactionKindCapture, Status: TransactionStatusSuccess, Amount: &captured},
+	}
+	if !reflect.DeepEqual(transactions, expected) {
+		t.Errorf("Transaction.List returned %+v, expected %+v", transactions, expected)
+	}
+}
+
+func TestTransactionCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/transactions/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 2}`))
+
+	cnt, err := client.Transaction.Count(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Errorf("Transaction.Count returned error: %v", err)
+	}
+
+	expected := 2
+	if cnt != expected {
+		t.Errorf("Transaction.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestTransactionGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/transactions/389404469.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("transaction.json")))
+
+	transaction, err := client.Transaction.Get(context.Background(), 450789469, 389404469, nil)
+	if err != nil {
+		t.Fatalf("Transaction.Get returned error: %v", err)
+	}
+
+	transactionTests(t, *transaction)
+}
+
+func TestTransactionCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/transactions.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &sent)
+			return httpmock.NewBytesResponse(201, loadFixture("transaction.json")), nil
+		})
+
+	amount := decimal.RequireFromString("10.00")
+	transaction := Transaction{
+		Kind:     TransactionKindCapture,
+		ParentID: 389404469,
+		Amount:   &amount,
+		Currency: "USD",
+	}
+
+	returnedTransaction, err := client.Transaction.Create(context.Background(), 450789469, transaction)
+	if err != nil {
+		t.Fatalf("Transaction.Create returned error: %v", err)
+	}
+
+	transactionTests(t, *returnedTransaction)
+
+	expected := map[string]map[string]interface{}{
+		"transaction": {
+			"kind":      "capture",
+			"parent_id": float64(389404469),
+			"amount":    "10",
+			"currency":  "USD",
+		},
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("Transaction.Create sent %+v, expected %+v", sent, expected)
+	}
+}
+
+func TestTransactionCreateValidationError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/transactions.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors": {"amount": ["must be greater than 0"], "kind": ["is not included in the list"]}}`))
+
+	_, err := client.Transaction.Create(context.Background(), 450789469, Transaction{Kind: "unknown"})
+	responseError, ok := err.(ResponseError)
+	if !ok {
+		t.Fatalf("Transaction.Create expected ResponseError, got %#v", err)
+	}
+
+	if responseError.Status != 422 {
+		t.Errorf("Transaction.Create error status returned %d, expected 422", responseError.Status)
+	}
+
+	expected := map[string][]string{
+		"amount": {"must be greater than 0"},
+		"kind":   {"is not included in the list"},
+	}
+	if !reflect.DeepEqual(responseError.FieldErrors, expected) {
+		t.Errorf("Transaction.Create error fields returned %+v, expected %+v", responseError.FieldErrors, expected)
+	}
+}
@@ -0,0 +1,330 @@
+package go_shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func variantTests(t *testing.T, variant Variant) {
+	// Check that the ID is assigned to the returned variant
+	var expectedInt int64 = 808950810
+	if variant.ID != expectedInt {
+		t.Errorf("Variant.ID returned %+v, expected %+v", variant.ID, expectedInt)
+	}
+
+	expectedTitle := "Pink"
+	if variant.Title != expectedTitle {
+		t.Errorf("Variant.Title returned %+v, expected %+v", variant.Title, expectedTitle)
+	}
+
+	decimalCases := []struct {
+		field    string
+		actual   *decimal.Decimal
+		expected string
+	}{
+		{"Price", variant.Price, "199.99"},
+		{"CompareAtPrice", variant.CompareAtPrice, "249.99"},
+		{"Weight", variant.Weight, "1.25"},
+	}
+	for _, c := range decimalCases {
+		expected := decimal.RequireFromString(c.expected)
+		if c.actual == nil || !c.actual.Equal(expected) {
+			t.Errorf("Variant.%s returned %v, expected %v", c.field, c.actual, expected)
+		}
+	}
+
+	if variant.Sku != "IPOD2008PINK" || variant.Barcode != "1234_pink" {
+		t.Errorf("Variant.Sku/Barcode returned %s/%s", variant.Sku, variant.Barcode)
+	}
+
+	if variant.InventoryItemID != 808950810 || variant.InventoryPolicy != VariantInventoryPolicyContinue {
+		t.Errorf("Variant.InventoryItemID/InventoryPolicy returned %d/%s", variant.InventoryItemID, variant.InventoryPolicy)
+	}
+
+	if variant.Taxable == nil || !*variant.Taxable || variant.RequiresShipping == nil || !*variant.RequiresShipping {
+		t.Errorf("Variant.Taxable/RequiresShipping returned %v/%v, expected true/true", variant.Taxable, variant.RequiresShipping)
+	}
+}
+
+func TestVariantList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392/variants.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("variants.json")))
+
+	variants, err := client.Variant.List(context.Background(), 632910392, nil)
+	if err != nil {
+		t.Errorf("Variant.List returned error: %v", err)
+	}
+
+	pink := decimal.RequireFromString("199.99")
+	red := decimal.RequireFromString("199.00")
+	expected := []Variant{
+		{ID: 808950810, ProductID: 632910392, Title: "Pink", Price: &pink, Sku: "IPOD2008PINK"},
+		{ID: 49148385, ProductID: 632910392, Title: "Red", Price: &red, Sku: "IPOD2008RED"},
+	}
+	if !reflect.DeepEqual(variants, expected) {
+		t.Errorf("Variant.List returned %+v, expected %+v", variants, expected)
+	}
+}
+
+func TestVariantCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/variants/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 3}`))
+
+	params := map[string]string{"created_at_min": "2016-01-01T00:00:00Z"}
+	httpmock.RegisterResponderWithQuery("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/variants/count.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"count": 2}`))
+
+	cnt, err := client.Variant.Count(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("Variant.Count returned error: %v", err)
+	}
+
+	expected := 3
+	if cnt != expected {
+		t.Errorf("Variant.Count returned %d, expected %d", cnt, expected)
+	}
+
+	date := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cnt, err = client.Variant.Count(context.Background(), 1, CountOptions{CreatedAtMin: date})
+	if err != nil {
+		t.Errorf("Variant.Count returned error: %v", err)
+	}
+
+	expected = 2
+	if cnt != expected {
+		t.Errorf("Variant.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestVariantGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/808950810.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("variant.json")))
+
+	variant, err := client.Variant.Get(context.Background(), 808950810, nil)
+	if err != nil {
+		t.Fatalf("Variant.Get returned error: %v", err)
+	}
+
+	variantTests(t, *variant)
+}
+
+func TestVariantCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/632910392/variants.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("variant.json")))
+
+	price := decimal.RequireFromString("199.99")
+	variant := Variant{
+		Option1: "Pink",
+		Price:   &price,
+	}
+	returnedVariant, err := client.Variant.Create(context.Background(), 632910392, variant)
+	if err != nil {
+		t.Fatalf("Variant.Create returned error: %v", err)
+	}
+
+	variantTests(t, *returnedVariant)
+}
+
+func TestVariantUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/808950810.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("variant.json")))
+
+	compareAtPrice := decimal.RequireFromString("249.99")
+	variant := Variant{
+		ID:             808950810,
+		CompareAtPrice: &compareAtPrice,
+	}
+
+	returnedVariant, err := client.Variant.Update(context.Background(), variant)
+	if err != nil {
+		t.Fatalf("Variant.Update returned error: %v", err)
+	}
+
+	variantTests(t, *returnedVariant)
+}
+
+func TestVariantUpdateFalse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/808950810.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("variant.json")), nil
+		})
+
+	no := false
+	_, err := client.Variant.Update(context.Background(), Variant{
+		ID:               808950810,
+		Taxable:          &no,
+		RequiresShipping: &no,
+	})
+	if err != nil {
+		t.Fatalf("Variant.Update returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":                float64(808950810),
+		"taxable":           false,
+		"requires_shipping": false,
+	}
+	if !reflect.DeepEqual(sent["variant"], expected) {
+		t.Errorf("Variant.Update sent %+v, expected %+v", sent["variant"], expected)
+	}
+}
+
+func TestVariantDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/variants/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Variant.Delete(context.Background(), 1, 2)
+	if err != nil {
+		t.Errorf("Variant.Delete returned error: %v", err)
+	}
+}
+
+func TestVariantListMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1/metafields.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":1},{"id":2}]}`))
+
+	metafields, err := client.Variant.ListMetafields(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("Variant.ListMetafields() returned error: %v", err)
+	}
+
+	expected := []Metafield{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Variant.ListMetafields() returned %+v, expected %+v", metafields, expected)
+	}
+}
+
+func TestVariantCountMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1/metafields/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 3}`))
+
+	cnt, err := client.Variant.CountMetafields(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("Variant.CountMetafields() returned error: %v", err)
+	}
+
+	expected := 3
+	if cnt != expected {
+		t.Errorf("Variant.CountMetafields() returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestVariantGetMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1/metafields/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafield": {"id":2}}`))
+
+	metafield, err := client.Variant.GetMetafield(context.Background(), 1, 2, nil)
+	if err != nil {
+		t.Errorf("Variant.GetMetafield() returned error: %v", err)
+	}
+
+	expected := &Metafield{ID: 2}
+	if !reflect.DeepEqual(metafield, expected) {
+		t.Errorf("Variant.GetMetafield() returned %+v, expected %+v", metafield, expected)
+	}
+}
+
+func TestVariantCreateMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1/metafields.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("metafield.json")))
+
+	metafield := Metafield{
+		Key:       "app_key",
+		Value:     "app_value",
+		Type:      "single_line_text_field",
+		Namespace: "affiliates",
+	}
+
+	returnedMetafield, err := client.Variant.CreateMetafield(context.Background(), 1, metafield)
+	if err != nil {
+		t.Fatalf("Variant.CreateMetafield() returned error: %v", err)
+	}
+
+	if returnedMetafield.ID != 721389482 || returnedMetafield.OwnerResource != "variant" {
+		t.Errorf("Variant.CreateMetafield() returned %+v", returnedMetafield)
+	}
+}
+
+func TestVariantUpdateMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1/metafields/721389482.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("metafield.json")))
+
+	metafield := Metafield{
+		ID:    721389482,
+		Value: "app_value",
+	}
+
+	returnedMetafield, err := client.Variant.UpdateMetafield(context.Background(), 1, metafield)
+	if err != nil {
+		t.Fatalf("Variant.UpdateMetafield() returned error: %v", err)
+	}
+
+	if returnedMetafield.ID != 721389482 {
+		t.Errorf("Variant.UpdateMetafield() returned %+v", returnedMetafield)
+	}
+}
+
+func TestVariantDeleteMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1/metafields/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Variant.DeleteMetafield(context.Background(), 1, 2)
+	if err != nil {
+		t.Errorf("Variant.DeleteMetafield() returned error: %v", err)
+	}
+}